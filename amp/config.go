@@ -0,0 +1,44 @@
+package amp
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// cacheHostSuffixes of the AMP cache origins which request the JSON variant on behalf of the page
+var cacheHostSuffixes = []string{".cdn.ampproject.org", ".ampproject.net"}
+
+// Config of the AMP endpoint
+type Config struct {
+	// AllowSourceOrigins of the AMP pages which can request the JSON variant,
+	// all requests are denied if empty
+	AllowSourceOrigins []string `json:"allow_source_origins" yaml:"allow_source_origins"`
+}
+
+// IsAllowedSourceOrigin returns true if the page origin can read the response
+func (conf *Config) IsAllowedSourceOrigin(origin string) bool {
+	return origin != "" && slices.Contains(conf.AllowSourceOrigins, origin)
+}
+
+// IsAllowedOrigin returns true if the `Origin` of the request is the allowed page
+// origin or the origin of the AMP cache
+func (conf *Config) IsAllowedOrigin(origin string) bool {
+	return conf.IsAllowedSourceOrigin(origin) || isCacheOrigin(origin)
+}
+
+// isCacheOrigin returns true if the origin is the HTTPS subdomain of the AMP cache
+func isCacheOrigin(origin string) bool {
+	link, err := url.Parse(origin)
+	if err != nil || link.Scheme != "https" || link.User != nil || link.Port() != "" ||
+		(link.Path != "" && link.Path != "/") || link.RawQuery != "" || link.Fragment != "" {
+		return false
+	}
+	host := strings.ToLower(link.Hostname())
+	for _, suffix := range cacheHostSuffixes {
+		if len(host) > len(suffix) && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
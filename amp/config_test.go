@@ -0,0 +1,34 @@
+package amp
+
+import "testing"
+
+func TestIsAllowedOrigin(t *testing.T) {
+	conf := &Config{AllowSourceOrigins: []string{"https://news.example.com"}}
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "", want: false},
+		{origin: "https://news.example.com", want: true},
+		{origin: "https://evil.example.com", want: false},
+		{origin: "https://news-example-com.cdn.ampproject.org", want: true},
+		{origin: "https://NEWS-EXAMPLE-COM.CDN.AMPPROJECT.ORG", want: true},
+		{origin: "https://d-123.ampproject.net", want: true},
+		{origin: "https://cdn.ampproject.org", want: false},
+		{origin: "https://.cdn.ampproject.org", want: false},
+		{origin: "http://news-example-com.cdn.ampproject.org", want: false},
+		{origin: "https://evil.com/.cdn.ampproject.org", want: false},
+		{origin: "https://x.cdn.ampproject.org.evil.com", want: false},
+		{origin: "https://x.cdn.ampproject.org:8443", want: false},
+		{origin: "https://user@x.cdn.ampproject.org", want: false},
+		{origin: "https://evilcdn.ampproject.org", want: false},
+	}
+	for _, tt := range tests {
+		if got := conf.IsAllowedOrigin(tt.origin); got != tt.want {
+			t.Errorf("IsAllowedOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+	if (&Config{}).IsAllowedSourceOrigin("https://news.example.com") {
+		t.Error("IsAllowedSourceOrigin() must deny all origins by default")
+	}
+}
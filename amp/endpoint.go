@@ -0,0 +1,142 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package amp
+
+import (
+	"encoding/json"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+type _endpoint struct {
+	urlGen   adtype.URLGenerator
+	conf     Config
+	renderer *templates.QTPLRenderer
+}
+
+// New creates new AMP endpoint
+func New(urlGen adtype.URLGenerator, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.renderer == nil {
+		e.renderer = templates.NewQTPLRenderer(urlGen, false)
+	}
+	return e
+}
+
+// Codename of the endpoint
+func (e *_endpoint) Codename() string {
+	return "amp"
+}
+
+// Handle request of the AMP ad and return response
+func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	ctx := request.HTTPRequest()
+	if string(ctx.QueryArgs().Peek("format")) == "json" {
+		if err := e.renderJSON(ctx, response); err != nil {
+			ctxlogger.Get(request.Context()).Error("amp render json", zap.Error(err))
+		}
+		return response
+	}
+	e.renderHTML(ctx, response)
+	return response
+}
+
+// renderHTML writes the AMPHTML ad document or `204 No Content` which collapses the `amp-ad` slot
+func (e *_endpoint) renderHTML(ctx *fasthttp.RequestCtx, response adtype.Response) {
+	it := templates.FirstResponseItem(response)
+	if response.Error() != nil || !templates.AMPSupported(it) {
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+		return
+	}
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetContentType("text/html; charset=UTF-8")
+	e.renderer.WriteAdRenderAMPBanner(ctx, response, it)
+}
+
+// renderJSON writes the items for `amp-list` with the AMP CORS headers
+func (e *_endpoint) renderJSON(ctx *fasthttp.RequestCtx, response adtype.Response) error {
+	resp := Response{Items: []*item{}}
+	if response.Error() == nil {
+		for _, ad := range response.Ads() {
+			it, _ := ad.(adtype.ResponseItem)
+			if !templates.AMPSupported(it) {
+				continue
+			}
+			resp.Items = append(resp.Items, e.prepareItem(it, response))
+		}
+	}
+	e.writeCORS(ctx)
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetContentType("application/json")
+	return json.NewEncoder(ctx).Encode(resp)
+}
+
+func (e *_endpoint) prepareItem(it adtype.ResponseItem, response adtype.Response) *item {
+	url, _ := e.urlGen.ClickURL(it, response)
+	res := &item{
+		ID:          it.ID(),
+		Type:        it.PriorityFormatType().Name(),
+		URL:         url,
+		Fields:      it.ContentFields(),
+		Impressions: append([]string{e.pixelURL(events.Impression, it, response)}, it.ImpressionTrackerLinks()...),
+		Views:       append([]string{e.pixelURL(events.View, it, response)}, it.ViewTrackerLinks()...),
+	}
+	if asset := it.MainAsset(); asset != nil {
+		res.Image = &image{
+			URL:    e.urlGen.CDNURL(asset.URL),
+			Width:  asset.Width,
+			Height: asset.Height,
+		}
+	}
+	return res
+}
+
+func (e *_endpoint) pixelURL(event events.Type, it adtype.ResponseItem, response adtype.Response) string {
+	url, _ := e.urlGen.PixelURL(event, events.StatusSuccess, it, response, false)
+	return url
+}
+
+// writeCORS sets the AMP CORS headers if the source origin of the page is allowed
+// and the request comes from the page itself or from the AMP cache
+func (e *_endpoint) writeCORS(ctx *fasthttp.RequestCtx) {
+	sourceOrigin := string(ctx.QueryArgs().Peek("__amp_source_origin"))
+	if !e.conf.IsAllowedSourceOrigin(sourceOrigin) {
+		return
+	}
+	header := &ctx.Response.Header
+	header.Add("Vary", "Origin")
+	switch origin := string(ctx.Request.Header.Peek("Origin")); {
+	case origin != "":
+		if !e.conf.IsAllowedOrigin(origin) {
+			return
+		}
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Set("Access-Control-Allow-Credentials", "true")
+	case string(ctx.Request.Header.Peek("AMP-Same-Origin")) != "true":
+		// The same-origin request of the AMP runtime has no Origin header
+		return
+	}
+	header.Set("AMP-Access-Control-Allow-Source-Origin", sourceOrigin)
+	header.Set("Access-Control-Expose-Headers", "AMP-Access-Control-Allow-Source-Origin")
+}
@@ -0,0 +1,61 @@
+package amp
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestWriteCORS(t *testing.T) {
+	const source = "https://news.example.com"
+	tests := []struct {
+		name         string
+		conf         Config
+		sourceOrigin string
+		origin       string
+		sameOrigin   bool
+		wantAllowed  bool
+		wantOrigin   string
+	}{
+		{name: "not_configured", sourceOrigin: source, origin: source},
+		{name: "cache", conf: Config{AllowSourceOrigins: []string{source}},
+			sourceOrigin: source, origin: "https://news-example-com.cdn.ampproject.org",
+			wantAllowed: true, wantOrigin: "https://news-example-com.cdn.ampproject.org"},
+		{name: "publisher", conf: Config{AllowSourceOrigins: []string{source}},
+			sourceOrigin: source, origin: source, wantAllowed: true, wantOrigin: source},
+		{name: "same_origin", conf: Config{AllowSourceOrigins: []string{source}},
+			sourceOrigin: source, sameOrigin: true, wantAllowed: true},
+		{name: "no_origin", conf: Config{AllowSourceOrigins: []string{source}},
+			sourceOrigin: source},
+		{name: "unknown_origin", conf: Config{AllowSourceOrigins: []string{source}},
+			sourceOrigin: source, origin: "https://evil.example.com"},
+		{name: "unknown_source", conf: Config{AllowSourceOrigins: []string{source}},
+			sourceOrigin: "https://evil.example.com", origin: "https://evil-example-com.cdn.ampproject.org"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx fasthttp.RequestCtx
+			ctx.Request.SetRequestURI("/amp?format=json&__amp_source_origin=" + tt.sourceOrigin)
+			if tt.origin != "" {
+				ctx.Request.Header.Set("Origin", tt.origin)
+			}
+			if tt.sameOrigin {
+				ctx.Request.Header.Set("AMP-Same-Origin", "true")
+			}
+			e := New(nil, WithConfig(tt.conf))
+			e.writeCORS(&ctx)
+
+			header := &ctx.Response.Header
+			allowed := string(header.Peek("AMP-Access-Control-Allow-Source-Origin"))
+			if (allowed != "") != tt.wantAllowed || (tt.wantAllowed && allowed != tt.sourceOrigin) {
+				t.Errorf("AMP-Access-Control-Allow-Source-Origin = %q, want allowed %v", allowed, tt.wantAllowed)
+			}
+			if got := string(header.Peek("Access-Control-Allow-Origin")); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if credentials := string(header.Peek("Access-Control-Allow-Credentials")); credentials != "" && tt.wantOrigin == "" {
+				t.Error("credentials must not be allowed without the validated origin")
+			}
+		})
+	}
+}
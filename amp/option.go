@@ -0,0 +1,20 @@
+package amp
+
+import "github.com/geniusrabbit/adstdendpoints/templates"
+
+// Option of the AMP endpoint
+type Option func(e *_endpoint)
+
+// WithConfig sets the whole endpoint config
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
+
+// WithRenderer sets the renderer of the AMPHTML documents with own view rules
+func WithRenderer(renderer *templates.QTPLRenderer) Option {
+	return func(e *_endpoint) {
+		e.renderer = renderer
+	}
+}
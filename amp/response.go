@@ -0,0 +1,26 @@
+package amp
+
+//easyjson:json
+type image struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+//easyjson:json
+type item struct {
+	ID          any            `json:"id"`
+	Type        string         `json:"type"`
+	URL         string         `json:"url,omitempty"`
+	Image       *image         `json:"image,omitempty"`
+	Fields      map[string]any `json:"fields,omitempty"`
+	Impressions []string       `json:"impressions,omitempty"`
+	Views       []string       `json:"views,omitempty"`
+}
+
+// Response of the JSON variant for `amp-list`
+//
+//easyjson:json
+type Response struct {
+	Items []*item `json:"items"`
+}
@@ -0,0 +1,44 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package debugaccess
+
+// Redaction policy keys
+const (
+	RedactCookies       = "cookies"
+	RedactAuthorization = "authorization"
+	RedactIP            = "ip"
+	RedactAdUnit        = "ad_unit"
+)
+
+// DefaultRedact list of fields hidden from the debug output if nothing is configured
+var DefaultRedact = []string{RedactCookies, RedactAuthorization, RedactIP}
+
+// Config of the debug access policy
+type Config struct {
+	// TokenSecret used to sign and verify debug tokens (HMAC-SHA256)
+	TokenSecret string `json:"token_secret" yaml:"token_secret"`
+
+	// TokenParam is the query parameter name with the signed token (default: `debug_token`)
+	TokenParam string `json:"token_param" yaml:"token_param"`
+
+	// AllowIPs list of IP addresses or CIDR networks which can use debug mode
+	AllowIPs []string `json:"allow_ips" yaml:"allow_ips"`
+
+	// TrustedProxies list of IP addresses or CIDR networks of the proxies and load balancers.
+	// The client IP is read from the proxy headers only if the connection comes from them,
+	// otherwise the remote address of the connection is used
+	TrustedProxies []string `json:"trusted_proxies" yaml:"trusted_proxies"`
+
+	// HeaderName of the request header with the debug secret (default: `X-Debug-Secret`)
+	HeaderName string `json:"header_name" yaml:"header_name"`
+
+	// HeaderSecret value which must be passed in the header
+	HeaderSecret string `json:"header_secret" yaml:"header_secret"`
+
+	// Redact list of fields hidden from the debug output:
+	// `cookies`, `authorization`, `ip`, `ad_unit` or any other header name
+	Redact []string `json:"redact" yaml:"redact"`
+}
@@ -0,0 +1,268 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package debugaccess
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+)
+
+// RedactedValue replaces hidden fields in the debug output
+const RedactedValue = "[redacted]"
+
+// DefaultTokenParam is the query parameter name of the debug token
+const DefaultTokenParam = "debug_token"
+
+// Access methods
+const (
+	MethodHeader = "header"
+	MethodToken  = "token"
+	MethodIP     = "ip"
+)
+
+var ipHeaders = map[string]bool{
+	"x-forwarded-for":  true,
+	"x-real-ip":        true,
+	"cf-connecting-ip": true,
+	"true-client-ip":   true,
+	"forwarded":        true,
+}
+
+// Policy decides whether the debug output is allowed for the request
+// and which fields must be hidden from it.
+// The nil policy denies debug mode for every request.
+type Policy struct {
+	tokenSecret  string
+	tokenParam   string
+	headerName   string
+	headerSecret string
+	networks     []*net.IPNet
+	proxies      []*net.IPNet
+	redact       map[string]bool
+}
+
+// New debug access policy from config
+func New(conf Config) (*Policy, error) {
+	policy := &Policy{
+		tokenSecret:  conf.TokenSecret,
+		tokenParam:   conf.TokenParam,
+		headerName:   conf.HeaderName,
+		headerSecret: conf.HeaderSecret,
+		redact:       map[string]bool{},
+	}
+	if policy.tokenParam == "" {
+		policy.tokenParam = DefaultTokenParam
+	}
+	if policy.headerName == "" {
+		policy.headerName = "X-Debug-Secret"
+	}
+	for _, addr := range conf.AllowIPs {
+		network, err := parseNetwork(addr)
+		if err != nil {
+			return nil, err
+		}
+		policy.networks = append(policy.networks, network)
+	}
+	for _, addr := range conf.TrustedProxies {
+		network, err := parseNetwork(addr)
+		if err != nil {
+			return nil, err
+		}
+		policy.proxies = append(policy.proxies, network)
+	}
+	redact := conf.Redact
+	if redact == nil {
+		redact = DefaultRedact
+	}
+	for _, field := range redact {
+		policy.redact[strings.ToLower(strings.TrimSpace(field))] = true
+	}
+	return policy, nil
+}
+
+// Granted returns true if the request asks for debug mode and passes
+// one of the access checks. Every granted access is written to the audit log,
+// denied requests are logged on the debug level only because any client can send them.
+func (p *Policy) Granted(request adtype.BidRequester) bool {
+	if p == nil || request == nil || !request.IsDebug() {
+		return false
+	}
+	var (
+		ctx    = request.HTTPRequest()
+		ip     = p.ClientIP(ctx)
+		method = p.accessMethod(ctx, ip)
+	)
+	if method == "" {
+		ctxlogger.Get(request.Context()).Debug("debug access denied",
+			zap.String("request_id", request.ID()),
+			zap.String("ip", ip),
+			zap.String("uri", p.URI(ctx)))
+		return false
+	}
+	ctxlogger.Get(request.Context()).Info("debug access granted",
+		zap.String("method", method),
+		zap.String("request_id", request.ID()),
+		zap.String("ip", ip),
+		zap.String("uri", p.URI(ctx)))
+	return true
+}
+
+// ClientIP returns the remote address of the connection, or the address from
+// the proxy headers if the connection comes from the trusted proxy
+func (p *Policy) ClientIP(ctx *fasthttp.RequestCtx) string {
+	remote := ctx.RemoteIP()
+	if p == nil || !containsIP(p.proxies, remote) {
+		return remote.String()
+	}
+	for _, header := range []string{"Cf-Connecting-Ip", "True-Client-Ip", "X-Real-Ip"} {
+		if ip := net.ParseIP(strings.TrimSpace(string(ctx.Request.Header.Peek(header)))); ip != nil {
+			return ip.String()
+		}
+	}
+	// The rightmost address which is not the trusted proxy is the client,
+	// addresses on the left are set by the client and can't be trusted
+	forwarded := strings.Split(string(ctx.Request.Header.Peek("X-Forwarded-For")), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			break
+		}
+		if !containsIP(p.proxies, ip) {
+			return ip.String()
+		}
+	}
+	return remote.String()
+}
+
+// URI returns the request URI without the debug token
+func (p *Policy) URI(ctx *fasthttp.RequestCtx) string {
+	uri := fasthttp.AcquireURI()
+	defer fasthttp.ReleaseURI(uri)
+	ctx.URI().CopyTo(uri)
+	uri.QueryArgs().Del(p.tokenParamName())
+	return string(uri.RequestURI())
+}
+
+// Query returns the query string of the request without the debug token
+func (p *Policy) Query(ctx *fasthttp.RequestCtx) string {
+	args := fasthttp.AcquireArgs()
+	defer fasthttp.ReleaseArgs(args)
+	ctx.QueryArgs().CopyTo(args)
+	args.Del(p.tokenParamName())
+	return args.String()
+}
+
+func (p *Policy) tokenParamName() string {
+	if p == nil || p.tokenParam == "" {
+		return DefaultTokenParam
+	}
+	return p.tokenParam
+}
+
+// IsRedacted returns true if the field must be hidden from the debug output
+func (p *Policy) IsRedacted(field string) bool {
+	if p == nil {
+		return true
+	}
+	return p.redact[strings.ToLower(field)]
+}
+
+// IP returns the IP address or the redacted value
+func (p *Policy) IP(ip string) string {
+	if p.IsRedacted(RedactIP) {
+		return RedactedValue
+	}
+	return ip
+}
+
+// Headers returns request headers with redacted values hidden
+func (p *Policy) Headers(ctx *fasthttp.RequestCtx) map[string]string {
+	headers := map[string]string{}
+	for key, value := range ctx.Request.Header.All() {
+		name := string(key)
+		if p.isRedactedHeader(name) {
+			headers[name] = RedactedValue
+		} else {
+			headers[name] = string(value)
+		}
+	}
+	return headers
+}
+
+func (p *Policy) isRedactedHeader(name string) bool {
+	lname := strings.ToLower(name)
+	switch {
+	case lname == "cookie" || lname == "set-cookie":
+		return p.IsRedacted(RedactCookies)
+	case lname == "authorization" || lname == "proxy-authorization":
+		return p.IsRedacted(RedactAuthorization)
+	case ipHeaders[lname]:
+		return p.IsRedacted(RedactIP)
+	case p != nil && strings.EqualFold(lname, p.headerName):
+		// Never expose the debug secret itself
+		return true
+	}
+	return p.IsRedacted(lname)
+}
+
+func (p *Policy) accessMethod(ctx *fasthttp.RequestCtx, ip string) string {
+	if p.headerSecret != "" {
+		secret := ctx.Request.Header.Peek(p.headerName)
+		if len(secret) > 0 && subtle.ConstantTimeCompare(secret, []byte(p.headerSecret)) == 1 {
+			return MethodHeader
+		}
+	}
+	if p.tokenSecret != "" {
+		if VerifyToken(p.tokenSecret, string(ctx.QueryArgs().Peek(p.tokenParam)), time.Now()) {
+			return MethodToken
+		}
+	}
+	if containsIP(p.networks, net.ParseIP(ip)) {
+		return MethodIP
+	}
+	return ""
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNetwork(addr string) (*net.IPNet, error) {
+	addr = strings.TrimSpace(addr)
+	if strings.Contains(addr, "/") {
+		_, network, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("debugaccess: invalid network %q: %w", addr, err)
+		}
+		return network, nil
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, fmt.Errorf("debugaccess: invalid IP address %q", addr)
+	}
+	bits := 128
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
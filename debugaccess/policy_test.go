@@ -0,0 +1,205 @@
+package debugaccess
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+func newRequestCtx(uri, remoteAddr string, headers map[string]string) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.SetRequestURI(uri)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&req, &net.TCPAddr{IP: net.ParseIP(remoteAddr), Port: 4000}, nil)
+	return ctx
+}
+
+func TestParseNetwork(t *testing.T) {
+	tests := []struct {
+		addr     string
+		contains []string
+		excludes []string
+		wantErr  bool
+	}{
+		{addr: "10.0.0.0/8", contains: []string{"10.1.2.3"}, excludes: []string{"11.0.0.1"}},
+		{addr: " 192.168.1.15 ", contains: []string{"192.168.1.15"}, excludes: []string{"192.168.1.16"}},
+		{addr: "2001:db8::/32", contains: []string{"2001:db8::1"}, excludes: []string{"2001:db9::1"}},
+		{addr: "2001:db8::1", contains: []string{"2001:db8::1"}, excludes: []string{"2001:db8::2"}},
+		{addr: "10.0.0.0/33", wantErr: true},
+		{addr: "10.0.0", wantErr: true},
+		{addr: "", wantErr: true},
+		{addr: "localhost", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			network, err := parseNetwork(tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNetwork() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, ip := range tt.contains {
+				if !network.Contains(net.ParseIP(ip)) {
+					t.Errorf("network %s must contain %s", network, ip)
+				}
+			}
+			for _, ip := range tt.excludes {
+				if network.Contains(net.ParseIP(ip)) {
+					t.Errorf("network %s must not contain %s", network, ip)
+				}
+			}
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	if _, err := New(Config{AllowIPs: []string{"invalid"}}); err == nil {
+		t.Error("invalid allow IP must return error")
+	}
+	if _, err := New(Config{TrustedProxies: []string{"10.0.0.0/99"}}); err == nil {
+		t.Error("invalid trusted proxy must return error")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	policy, err := New(Config{AllowIPs: []string{"10.0.0.1"}, TrustedProxies: []string{"172.16.0.0/12"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:    "untrusted_forwarded_for",
+			remote:  "203.0.113.7",
+			headers: map[string]string{"X-Forwarded-For": "10.0.0.1"},
+			want:    "203.0.113.7",
+		},
+		{
+			name:    "untrusted_cloudflare",
+			remote:  "203.0.113.7",
+			headers: map[string]string{"Cf-Connecting-Ip": "10.0.0.1"},
+			want:    "203.0.113.7",
+		},
+		{
+			name:    "trusted_cloudflare",
+			remote:  "172.16.0.2",
+			headers: map[string]string{"Cf-Connecting-Ip": "198.51.100.4"},
+			want:    "198.51.100.4",
+		},
+		{
+			name:    "trusted_forwarded_for_rightmost",
+			remote:  "172.16.0.2",
+			headers: map[string]string{"X-Forwarded-For": "10.0.0.1, 198.51.100.4, 172.16.0.9"},
+			want:    "198.51.100.4",
+		},
+		{
+			name:    "trusted_invalid_forwarded_for",
+			remote:  "172.16.0.2",
+			headers: map[string]string{"X-Forwarded-For": "10.0.0.1, garbage"},
+			want:    "172.16.0.2",
+		},
+		{
+			name:   "trusted_without_headers",
+			remote: "172.16.0.2",
+			want:   "172.16.0.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newRequestCtx("/b/dynamic/1", tt.remote, tt.headers)
+			ip := policy.ClientIP(ctx)
+			if ip != tt.want {
+				t.Errorf("ClientIP() = %s, want %s", ip, tt.want)
+			}
+			if method := policy.accessMethod(ctx, ip); (method == MethodIP) != (ip == "10.0.0.1") {
+				t.Errorf("accessMethod() = %q for IP %s", method, ip)
+			}
+		})
+	}
+}
+
+func TestAccessMethod(t *testing.T) {
+	policy, err := New(Config{TokenSecret: "token-secret", HeaderSecret: "header-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := SignToken("token-secret", time.Now().Add(time.Hour))
+	tests := []struct {
+		name    string
+		uri     string
+		headers map[string]string
+		want    string
+	}{
+		{name: "header", uri: "/", headers: map[string]string{"X-Debug-Secret": "header-secret"}, want: MethodHeader},
+		{name: "wrong_header", uri: "/", headers: map[string]string{"X-Debug-Secret": "header-secreT"}},
+		{name: "token", uri: "/?debug_token=" + token, want: MethodToken},
+		{name: "wrong_token", uri: "/?debug_token=" + token + "x"},
+		{name: "nothing", uri: "/?debug=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newRequestCtx(tt.uri, "203.0.113.7", tt.headers)
+			if method := policy.accessMethod(ctx, policy.ClientIP(ctx)); method != tt.want {
+				t.Errorf("accessMethod() = %q, want %q", method, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactedOutput(t *testing.T) {
+	policy, err := New(Config{TokenSecret: "token-secret", HeaderSecret: "header-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := SignToken("token-secret", time.Now().Add(time.Hour))
+	ctx := newRequestCtx("/b/dynamic/1?zone=1&debug_token="+token+"&debug=1", "203.0.113.7", map[string]string{
+		"Cookie":          "session=cookie-value",
+		"Authorization":   "Bearer auth-value",
+		"X-Debug-Secret":  "header-secret",
+		"X-Forwarded-For": "198.51.100.4",
+		"User-Agent":      "test-agent",
+	})
+
+	for name, value := range map[string]string{"uri": policy.URI(ctx), "query": policy.Query(ctx)} {
+		if strings.Contains(value, token) || strings.Contains(value, "debug_token") {
+			t.Errorf("%s contains the debug token: %s", name, value)
+		}
+		if !strings.Contains(value, "zone=1") || !strings.Contains(value, "debug=1") {
+			t.Errorf("%s lost other parameters: %s", name, value)
+		}
+	}
+	if !strings.HasPrefix(policy.URI(ctx), "/b/dynamic/1?") {
+		t.Errorf("URI() lost the path: %s", policy.URI(ctx))
+	}
+
+	headers := policy.Headers(ctx)
+	for _, secret := range []string{"cookie-value", "auth-value", "header-secret", "198.51.100.4"} {
+		for name, value := range headers {
+			if strings.Contains(value, secret) {
+				t.Errorf("header %s contains the secret %q", name, secret)
+			}
+		}
+	}
+	if headers["User-Agent"] != "test-agent" {
+		t.Errorf("User-Agent must not be redacted: %q", headers["User-Agent"])
+	}
+	if ip := policy.IP("203.0.113.7"); ip != RedactedValue {
+		t.Errorf("IP() = %s, want redacted", ip)
+	}
+
+	var nilPolicy *Policy
+	if !nilPolicy.IsRedacted(RedactAdUnit) || nilPolicy.IP("203.0.113.7") != RedactedValue {
+		t.Error("nil policy must redact everything")
+	}
+	if uri := nilPolicy.URI(ctx); strings.Contains(uri, token) {
+		t.Errorf("nil policy URI contains the debug token: %s", uri)
+	}
+}
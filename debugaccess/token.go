@@ -0,0 +1,49 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package debugaccess
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignToken generates debug token valid till expiration time
+//
+// Token format: `{unix-expiration}.{base64url(hmac-sha256(secret, unix-expiration))}`
+func SignToken(secret string, expires time.Time) string {
+	payload := strconv.FormatInt(expires.Unix(), 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(tokenSign(secret, payload))
+}
+
+// VerifyToken checks the signature and expiration of the debug token
+func VerifyToken(secret, token string, now time.Time) bool {
+	if secret == "" || token == "" {
+		return false
+	}
+	payload, sign, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil || now.Unix() > expires {
+		return false
+	}
+	signBytes, err := base64.RawURLEncoding.DecodeString(sign)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(signBytes, tokenSign(secret, payload))
+}
+
+func tokenSign(secret, payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
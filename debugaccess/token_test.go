@@ -0,0 +1,43 @@
+package debugaccess
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyToken(t *testing.T) {
+	var (
+		now   = time.Unix(1700000000, 0)
+		valid = SignToken("secret", now.Add(time.Hour))
+	)
+	payload, sign, _ := strings.Cut(valid, ".")
+	tampered := []byte(sign)
+	tampered[0] ^= 1
+
+	tests := []struct {
+		name   string
+		secret string
+		token  string
+		want   bool
+	}{
+		{name: "valid", secret: "secret", token: valid, want: true},
+		{name: "expired", secret: "secret", token: SignToken("secret", now.Add(-time.Second))},
+		{name: "wrong_secret", secret: "other", token: valid},
+		{name: "empty_secret", secret: "", token: valid},
+		{name: "empty_token", secret: "secret", token: ""},
+		{name: "no_separator", secret: "secret", token: payload + sign},
+		{name: "invalid_expiration", secret: "secret", token: "abc." + sign},
+		{name: "invalid_base64", secret: "secret", token: payload + ".!!!"},
+		{name: "tampered_signature", secret: "secret", token: payload + "." + string(tampered)},
+		{name: "tampered_expiration", secret: "secret", token: "9" + payload + "." + sign},
+		{name: "truncated_signature", secret: "secret", token: payload + "." + sign[:len(sign)-4]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyToken(tt.secret, tt.token, now); got != tt.want {
+				t.Errorf("VerifyToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package debuginfo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+	"github.com/geniusrabbit/adcorelib/eventtraking/eventstream"
+)
+
+// Bid outcomes of the source
+const (
+	OutcomeWin   = "win"
+	OutcomeLost  = "lost"
+	OutcomeNoBid = "nobid"
+	OutcomeSkip  = "skip"
+	OutcomeError = "error"
+)
+
+// SourceSummary of the bid outcome for one source
+type SourceSummary struct {
+	ID       uint64   `json:"id"`
+	Protocol string   `json:"protocol,omitempty"`
+	Outcome  string   `json:"outcome,omitempty"`
+	Bids     int      `json:"bids"`
+	MaxECPM  float64  `json:"max_ecpm,omitempty"`
+	AdIDs    []string `json:"ad_ids,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// AuctionSummary of the response
+type AuctionSummary struct {
+	AuctionID string           `json:"auction_id,omitempty"`
+	Source    *SourceSummary   `json:"source,omitempty"`
+	Sources   []*SourceSummary `json:"sources,omitempty"`
+	Count     int              `json:"count"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// AuctionRecorder collects bid outcomes of the sources from the source events
+// of the request event stream. The events are forwarded to the original stream.
+// Only the sources reported by the wrapper are recorded, the sources which were
+// not queried or didn't respond before the response was rendered are not listed.
+// Auction is safe for the nil recorder, so recording can be disabled
+// by passing nil without additional checks.
+type AuctionRecorder struct {
+	mx      sync.Mutex
+	parent  eventstream.Stream
+	sources []*SourceSummary
+}
+
+// NewAuctionRecorder attaches the recorder to the request context
+func NewAuctionRecorder(request adtype.BidRequester) *AuctionRecorder {
+	ctx := request.Context()
+	rec := &AuctionRecorder{}
+	rec.parent, _ = ctx.Value(eventstream.CtxStreamObject).(eventstream.Stream)
+	request.SetContext(eventstream.WithStream(ctx, rec))
+	return rec
+}
+
+// Auction returns the summary of bid outcomes grouped by source. Without the recorder
+// only the sources of the winning ads are reported.
+func (r *AuctionRecorder) Auction(response adtype.Response) *AuctionSummary {
+	if response == nil {
+		return nil
+	}
+	summary := &AuctionSummary{Count: response.Count()}
+	if req := response.Request(); req != nil {
+		summary.AuctionID = req.AuctionID()
+	}
+	if err := response.Error(); err != nil {
+		summary.Error = err.Error()
+	}
+	if src := response.Source(); src != nil {
+		summary.Source = &SourceSummary{ID: src.ID(), Protocol: src.Protocol()}
+	}
+
+	winners := map[uint64][]adtype.ResponseItem{}
+	for item := range response.IterAds() {
+		if src := item.Source(); src != nil {
+			winners[src.ID()] = append(winners[src.ID()], item)
+		}
+	}
+
+	if r == nil {
+		for item := range response.IterAds() {
+			if src := item.Source(); src != nil {
+				summary.sourceOrCreate(src)
+			}
+		}
+	} else {
+		r.mx.Lock()
+		for _, sum := range r.sources {
+			cp := *sum
+			cp.AdIDs = append([]string(nil), sum.AdIDs...)
+			summary.Sources = append(summary.Sources, &cp)
+		}
+		r.mx.Unlock()
+	}
+
+	for _, sum := range summary.Sources {
+		items := winners[sum.ID]
+		switch {
+		case len(items) > 0:
+			sum.Outcome = OutcomeWin
+			if r == nil {
+				sum.Bids = len(items)
+				for _, item := range items {
+					sum.AdIDs = append(sum.AdIDs, item.AdID())
+					sum.MaxECPM = max(sum.MaxECPM, item.ECPM().Float64())
+				}
+			}
+		case sum.Bids > 0:
+			sum.Outcome = OutcomeLost
+		}
+	}
+	return summary
+}
+
+func (s *AuctionSummary) sourceOrCreate(src adtype.Source) *SourceSummary {
+	for _, sum := range s.Sources {
+		if sum.ID == src.ID() {
+			return sum
+		}
+	}
+	sum := &SourceSummary{ID: src.ID(), Protocol: src.Protocol()}
+	s.Sources = append(s.Sources, sum)
+	return sum
+}
+
+// source summary by the source object, must be called under the lock
+func (r *AuctionRecorder) source(src adtype.Source) *SourceSummary {
+	for _, sum := range r.sources {
+		if sum.ID == src.ID() {
+			return sum
+		}
+	}
+	sum := &SourceSummary{ID: src.ID(), Protocol: src.Protocol()}
+	r.sources = append(r.sources, sum)
+	return sum
+}
+
+// record the source event of the response
+func (r *AuctionRecorder) record(event events.Type, response adtype.Response, it adtype.ResponseItem) {
+	var src adtype.Source
+	if it != nil && event == events.SourceBid {
+		src = it.Source()
+	}
+	if src == nil && response != nil {
+		src = response.Source()
+	}
+	if src == nil {
+		return
+	}
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	sum := r.source(src)
+	switch event {
+	case events.SourceBid:
+		sum.Bids++
+		sum.Outcome = ""
+		if it != nil {
+			sum.AdIDs = append(sum.AdIDs, it.AdID())
+			sum.MaxECPM = max(sum.MaxECPM, it.ECPM().Float64())
+		}
+	case events.SourceNoBid:
+		if sum.Bids == 0 {
+			sum.Outcome = OutcomeNoBid
+		}
+	case events.SourceSkip:
+		sum.Outcome = OutcomeSkip
+	case events.SourceFail:
+		sum.Outcome = OutcomeError
+		if err := response.Error(); err != nil {
+			sum.Error = err.Error()
+		}
+	}
+}
+
+// SendEvent implements eventstream.Stream
+func (r *AuctionRecorder) SendEvent(ctx context.Context, event any) error {
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendEvent(ctx, event)
+}
+
+// Send implements eventstream.Stream and records the source events
+func (r *AuctionRecorder) Send(event events.Type, status uint8, response adtype.Response, it adtype.ResponseItem) error {
+	switch event {
+	case events.SourceBid, events.SourceNoBid, events.SourceSkip, events.SourceFail:
+		r.record(event, response, it)
+	}
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.Send(event, status, response, it)
+}
+
+// SendLeadEvent implements eventstream.Stream
+func (r *AuctionRecorder) SendLeadEvent(ctx context.Context, event any) error {
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendLeadEvent(ctx, event)
+}
+
+// SendSourceSkip implements eventstream.Stream and records the skip outcome
+func (r *AuctionRecorder) SendSourceSkip(response adtype.Response) error {
+	r.record(events.SourceSkip, response, nil)
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendSourceSkip(response)
+}
+
+// SendSourceNoBid implements eventstream.Stream and records the no-bid outcome
+func (r *AuctionRecorder) SendSourceNoBid(response adtype.Response) error {
+	r.record(events.SourceNoBid, response, nil)
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendSourceNoBid(response)
+}
+
+// SendSourceFail implements eventstream.Stream and records the error outcome
+func (r *AuctionRecorder) SendSourceFail(response adtype.Response) error {
+	r.record(events.SourceFail, response, nil)
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendSourceFail(response)
+}
+
+// SendAccessPointBid implements eventstream.Stream
+func (r *AuctionRecorder) SendAccessPointBid(response adtype.Response, it ...adtype.ResponseItem) error {
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendAccessPointBid(response, it...)
+}
+
+// SendAccessPointSkip implements eventstream.Stream
+func (r *AuctionRecorder) SendAccessPointSkip(response adtype.Response) error {
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendAccessPointSkip(response)
+}
+
+// SendAccessPointNoBid implements eventstream.Stream
+func (r *AuctionRecorder) SendAccessPointNoBid(response adtype.Response) error {
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendAccessPointNoBid(response)
+}
+
+// SendAccessPointFail implements eventstream.Stream
+func (r *AuctionRecorder) SendAccessPointFail(response adtype.Response) error {
+	if r.parent == nil {
+		return nil
+	}
+	return r.parent.SendAccessPointFail(response)
+}
+
+var _ eventstream.Stream = (*AuctionRecorder)(nil)
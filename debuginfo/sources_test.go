@@ -0,0 +1,161 @@
+package debuginfo
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+	"github.com/geniusrabbit/adcorelib/eventtraking/eventstream"
+)
+
+type testSource struct {
+	adtype.SourceEmpty
+	id uint64
+}
+
+func (s *testSource) ID() uint64       { return s.id }
+func (s *testSource) Protocol() string { return "test" }
+
+type testAccessor struct {
+	sources []adtype.Source
+}
+
+func (a *testAccessor) Iterator(adtype.BidRequester) adtype.SourceIterator {
+	return iter.Seq2[float32, adtype.Source](func(yield func(float32, adtype.Source) bool) {
+		for _, src := range a.sources {
+			if !yield(1, src) {
+				return
+			}
+		}
+	})
+}
+
+func (a *testAccessor) SourceByID(context.Context, uint64) (adtype.Source, error) { return nil, nil }
+func (a *testAccessor) SetTimeout(context.Context, time.Duration)                 {}
+
+type testWrapper struct {
+	adtype.SourceEmpty
+	accessor *testAccessor
+}
+
+func (w *testWrapper) Sources() adtype.SourceAccessor { return w.accessor }
+
+// limitWrapper queries the sources up to the parallel limit like the multisource wrapper
+// and reports the bids of the queried sources to the request event stream
+type limitWrapper struct {
+	testWrapper
+	limit int
+}
+
+func (w *limitWrapper) Bid(request adtype.BidRequester) adtype.Response {
+	var (
+		count int
+		items []adtype.ResponseItemCommon
+	)
+	for _, src := range w.accessor.Iterator(request) {
+		if count++; count > w.limit {
+			break
+		}
+		item := &bidresponse.ResponseItemBlank{ItemID: "ad" + strconv.FormatUint(src.ID(), 10), Imp: request.Impressions()[0], Src: src}
+		resp := bidresponse.NewResponse(request, src, []adtype.ResponseItemCommon{item}, nil)
+		_ = eventstream.StreamFromContext(resp.Context()).Send(events.SourceBid, events.StatusSuccess, resp, item)
+		items = append(items, item)
+	}
+	return bidresponse.NewResponse(request, w, items[:1], nil)
+}
+
+func TestAuctionRecorder(t *testing.T) {
+	var (
+		imp     = &adtype.Impression{ID: "imp1", Target: &adtype.TargetEmpty{}}
+		request = &bidrequest.BidRequest{IDVal: "auc1", Ctx: context.Background(), Imps: []*adtype.Impression{imp}}
+		winner  = &testSource{id: 1}
+		loser   = &testSource{id: 2}
+		noBid   = &testSource{id: 3}
+		failed  = &testSource{id: 4}
+		skipped = &testSource{id: 5}
+		silent  = &testSource{id: 6}
+		wrapper = &testWrapper{accessor: &testAccessor{
+			sources: []adtype.Source{winner, loser, noBid, failed, skipped, silent},
+		}}
+	)
+	rec := NewAuctionRecorder(request)
+	stream, _ := request.Context().Value(eventstream.CtxStreamObject).(eventstream.Stream)
+	if stream != rec {
+		t.Fatal("recorder must be attached to the request context")
+	}
+
+	winItem := &bidresponse.ResponseItemBlank{ItemID: "win", Imp: imp, Src: winner}
+	lostItem := &bidresponse.ResponseItemBlank{ItemID: "lost", Imp: imp, Src: loser}
+	_ = stream.Send(events.SourceBid, events.StatusSuccess, bidresponse.NewResponse(request, winner, []adtype.ResponseItemCommon{winItem}, nil), winItem)
+	_ = stream.Send(events.SourceBid, events.StatusSuccess, bidresponse.NewResponse(request, loser, []adtype.ResponseItemCommon{lostItem}, nil), lostItem)
+	_ = stream.SendSourceNoBid(bidresponse.NewEmptyResponse(request, noBid, nil))
+	_ = stream.SendSourceFail(bidresponse.NewEmptyResponse(request, failed, errors.New("connection refused")))
+	_ = stream.SendSourceSkip(bidresponse.NewEmptyResponse(request, skipped, nil))
+
+	summary := rec.Auction(bidresponse.NewResponse(request, wrapper, []adtype.ResponseItemCommon{winItem}, nil))
+	want := map[uint64]string{
+		1: OutcomeWin,
+		2: OutcomeLost,
+		3: OutcomeNoBid,
+		4: OutcomeError,
+		5: OutcomeSkip,
+	}
+	if len(summary.Sources) != len(want) {
+		t.Fatalf("sources count = %d, want %d", len(summary.Sources), len(want))
+	}
+	for _, sum := range summary.Sources {
+		if sum.Outcome != want[sum.ID] {
+			t.Errorf("source %d outcome = %q, want %q", sum.ID, sum.Outcome, want[sum.ID])
+		}
+	}
+	if summary.Sources[3].Error != "connection refused" {
+		t.Errorf("source error = %q", summary.Sources[3].Error)
+	}
+	if summary.Sources[1].Bids != 1 {
+		t.Errorf("lost source bids = %d, want 1", summary.Sources[1].Bids)
+	}
+}
+
+func TestAuctionWithoutRecorder(t *testing.T) {
+	var (
+		imp     = &adtype.Impression{ID: "imp1", Target: &adtype.TargetEmpty{}}
+		request = &bidrequest.BidRequest{IDVal: "auc1", Ctx: context.Background(), Imps: []*adtype.Impression{imp}}
+		src     = &testSource{id: 1}
+		item    = &bidresponse.ResponseItemBlank{ItemID: "win", Imp: imp, Src: src}
+		rec     *AuctionRecorder
+	)
+	summary := rec.Auction(bidresponse.NewResponse(request, src, []adtype.ResponseItemCommon{item}, nil))
+	if len(summary.Sources) != 1 || summary.Sources[0].Outcome != OutcomeWin || summary.Sources[0].Bids != 1 {
+		t.Errorf("unexpected summary: %+v", summary.Sources)
+	}
+}
+
+func TestAuctionRecorderParallelLimit(t *testing.T) {
+	var (
+		imp      = &adtype.Impression{ID: "imp1", Target: &adtype.TargetEmpty{}}
+		request  = &bidrequest.BidRequest{IDVal: "auc1", Ctx: context.Background(), Imps: []*adtype.Impression{imp}}
+		accessor = &testAccessor{}
+		wrapper  = &limitWrapper{testWrapper: testWrapper{accessor: accessor}, limit: 2}
+	)
+	for id := uint64(1); id <= 5; id++ {
+		accessor.sources = append(accessor.sources, &testSource{id: id})
+	}
+	rec := NewAuctionRecorder(request)
+	summary := rec.Auction(wrapper.Bid(request))
+	want := map[uint64]string{1: OutcomeWin, 2: OutcomeLost}
+	if len(summary.Sources) != len(want) {
+		t.Fatalf("sources count = %d, want %d of the queried sources: %+v", len(summary.Sources), len(want), summary.Sources)
+	}
+	for _, sum := range summary.Sources {
+		if sum.Outcome != want[sum.ID] {
+			t.Errorf("source %d outcome = %q, want %q", sum.ID, sum.Outcome, want[sum.ID])
+		}
+	}
+}
@@ -0,0 +1,120 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package debuginfo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Processing stages of the request
+const (
+	StageParse    = "parse"
+	StageBid      = "bid"
+	StageValidate = "validate"
+	StageURLs     = "urls"
+	StageRender   = "render"
+	StageEncode   = "encode"
+)
+
+// Stage of the request processing
+type Stage struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"-"`
+}
+
+// Milliseconds of the stage duration
+func (s Stage) Milliseconds() float64 {
+	return float64(s.Duration.Microseconds()) / 1000
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Stage) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, len(s.Name)+32)
+	buf = append(buf, `{"name":`...)
+	buf = strconv.AppendQuote(buf, s.Name)
+	buf = append(buf, `,"ms":`...)
+	buf = strconv.AppendFloat(buf, s.Milliseconds(), 'f', 3, 64)
+	return append(buf, '}'), nil
+}
+
+// Timing collects durations of the request processing stages.
+// All methods are safe for the nil object, so timing can be disabled
+// by passing nil without additional checks.
+type Timing struct {
+	stages []Stage
+}
+
+// NewTiming object
+func NewTiming() *Timing {
+	return &Timing{}
+}
+
+// Start measuring of the stage and returns the stop function
+func (t *Timing) Start(name string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { t.Add(name, time.Since(start)) }
+}
+
+// Since adds the stage measured from the start time till now
+func (t *Timing) Since(name string, start time.Time) {
+	if t == nil || start.IsZero() {
+		return
+	}
+	t.Add(name, time.Since(start))
+}
+
+// Add duration to the stage, durations of the same stage are summed
+func (t *Timing) Add(name string, duration time.Duration) {
+	if t == nil {
+		return
+	}
+	for i := range t.stages {
+		if t.stages[i].Name == name {
+			t.stages[i].Duration += duration
+			return
+		}
+	}
+	t.stages = append(t.stages, Stage{Name: name, Duration: duration})
+}
+
+// Stages list in order of the first measurement
+func (t *Timing) Stages() []Stage {
+	if t == nil {
+		return nil
+	}
+	return t.stages
+}
+
+// ServerTiming returns the value of the `Server-Timing` header
+func (t *Timing) ServerTiming() string {
+	if t == nil || len(t.stages) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	for i, stage := range t.stages {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(stage.Name)
+		buf.WriteString(";dur=")
+		buf.WriteString(strconv.FormatFloat(stage.Milliseconds(), 'f', 3, 64))
+	}
+	return buf.String()
+}
+
+// WriteHeader sets the `Server-Timing` header to the response
+func (t *Timing) WriteHeader(ctx *fasthttp.RequestCtx) {
+	if value := t.ServerTiming(); value != "" {
+		ctx.Response.Header.Set("Server-Timing", value)
+	}
+}
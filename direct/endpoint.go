@@ -21,6 +21,8 @@ import (
 	"github.com/geniusrabbit/adcorelib/eventtraking/eventstream"
 	"github.com/geniusrabbit/adcorelib/gtracing"
 	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/debugaccess"
+	"github.com/geniusrabbit/adstdendpoints/debuginfo"
 )
 
 // Error list...
@@ -32,13 +34,18 @@ var (
 type _endpoint struct {
 	formats          types.FormatsAccessor
 	superFailoverURL string
+	debugAccess      *debugaccess.Policy
 }
 
-func New(formats types.FormatsAccessor, superFailoverURL string) *_endpoint {
-	return &_endpoint{
+func New(formats types.FormatsAccessor, superFailoverURL string, opts ...Option) *_endpoint {
+	e := &_endpoint{
 		formats:          formats,
 		superFailoverURL: superFailoverURL,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 func (e *_endpoint) Codename() string {
@@ -53,16 +60,29 @@ func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidReque
 		return true
 	})
 	newRequest := request.WithFormats(e.formats)
+	debug := newRequest.HTTPRequest().QueryArgs().Has("noredirect") &&
+		e.debugAccess.Granted(newRequest)
+	var (
+		timing   *debuginfo.Timing
+		recorder *debuginfo.AuctionRecorder
+	)
+	if debug {
+		timing = debuginfo.NewTiming()
+		recorder = debuginfo.NewAuctionRecorder(newRequest)
+	}
+	timing.Since(debuginfo.StageParse, newRequest.HTTPRequest().Time())
+	stopBid := timing.Start(debuginfo.StageBid)
 	response := source.Bid(newRequest)
-	if err := e.execDirect(newRequest.HTTPRequest(), response); err != nil {
+	stopBid()
+	if err := e.execDirect(newRequest.HTTPRequest(), response, debug, timing, recorder); err != nil {
 		ctxlogger.Get(newRequest.Context()).Error("exec direct", zap.Error(err))
 	} else {
-		e.sendViewEvent(response)
+		e.sendViewEvent(response, debug)
 	}
 	return response
 }
 
-func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Response) (err error) {
+func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Response, debug bool, timing *debuginfo.Timing, recorder *debuginfo.AuctionRecorder) (err error) {
 	var (
 		id              string
 		zoneID          uint64
@@ -87,7 +107,7 @@ func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Respons
 				}
 			}
 		}
-	} else if err = response.Validate(); err == nil {
+	} else if err = e.validate(response, timing); err == nil {
 		if response.Count() > 1 {
 			err = ErrMultipleDirectNotSupported
 		} else {
@@ -103,7 +123,9 @@ func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Respons
 				if !ad.IsDirect() {
 					err = ErrInvalidResponseType
 				} else {
+					stopURLs := timing.Start(debuginfo.StageURLs)
 					link = adtype.PrepareURL(ad.ActionURL(), response, ad)
+					stopURLs()
 				}
 			case adtype.ResponseMultipleItem:
 				err = ErrMultipleDirectNotSupported
@@ -114,10 +136,8 @@ func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Respons
 	}
 
 	switch {
-	case response != nil && response.Request().IsDebug() && req.QueryArgs().Has("noredirect"):
-		req.SetStatusCode(http.StatusOK)
-		req.SetContentType("application/json")
-		_ = json.NewEncoder(req).Encode(debugResponse{
+	case response != nil && debug:
+		debugResp := debugResponse{
 			ID:                id,
 			ZoneID:            zoneID,
 			ImpressionID:      impID,
@@ -127,7 +147,16 @@ func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Respons
 			Superfailover:     e.superFailoverURL,
 			Error:             err,
 			IsEmpty:           response.Count() < 1,
-		})
+			Auction:           recorder.Auction(response),
+			Timing:            timing.Stages(),
+		}
+		req.SetStatusCode(http.StatusOK)
+		req.SetContentType("application/json")
+		// The encoding is measured by the real write, so it's reported in the header only
+		stopEncode := timing.Start(debuginfo.StageEncode)
+		_ = json.NewEncoder(req).Encode(debugResp)
+		stopEncode()
+		timing.WriteHeader(req)
 	case link != "":
 		req.Response.Header.Set("X-Status-Alternative", "1")
 		req.Redirect(link, http.StatusFound)
@@ -140,11 +169,16 @@ func (e *_endpoint) execDirect(req *fasthttp.RequestCtx, response adtype.Respons
 	return err
 }
 
-func (e *_endpoint) sendViewEvent(response adtype.Response) {
+func (e *_endpoint) validate(response adtype.Response, timing *debuginfo.Timing) error {
+	defer timing.Start(debuginfo.StageValidate)()
+	return response.Validate()
+}
+
+func (e *_endpoint) sendViewEvent(response adtype.Response, debug bool) {
 	if response == nil || response.Error() != nil || len(response.Ads()) == 0 {
 		return
 	}
-	if response.Request().IsDebug() && response.Request().HTTPRequest().QueryArgs().Has("noredirect") {
+	if debug {
 		ctxlogger.Get(response.Context()).Info("skip event log",
 			zap.String("request_id", response.Request().ID()))
 		return
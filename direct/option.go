@@ -0,0 +1,13 @@
+package direct
+
+import "github.com/geniusrabbit/adstdendpoints/debugaccess"
+
+// Option of the direct endpoint
+type Option func(e *_endpoint)
+
+// WithDebugAccess sets the policy which grants access to the debug output
+func WithDebugAccess(policy *debugaccess.Policy) Option {
+	return func(e *_endpoint) {
+		e.debugAccess = policy
+	}
+}
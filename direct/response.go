@@ -1,5 +1,7 @@
 package direct
 
+import "github.com/geniusrabbit/adstdendpoints/debuginfo"
+
 type debugResponse struct {
 	ID                string `json:"id,omitempty"`
 	ZoneID            uint64 `json:"zone_id,omitempty"`
@@ -10,4 +12,7 @@ type debugResponse struct {
 	Superfailover     string `json:"superfailover,omitempty"`
 	Error             error  `json:"error,omitempty"`
 	IsEmpty           bool   `json:"is_empty,omitempty"`
+
+	Auction *debuginfo.AuctionSummary `json:"auction,omitempty"`
+	Timing  []debuginfo.Stage         `json:"timing,omitempty"`
 }
@@ -4,3 +4,31 @@ type MetaConfig struct {
 	ComplaintAdURL string `json:"complaint_ad_url" yaml:"complaint_ad_url"`
 	AboutAdURL     string `json:"about_ad_url" yaml:"about_ad_url"`
 }
+
+// Tracker pixel modes
+const (
+	TrackerModeImage = "img"  // Image pixels only (default)
+	TrackerModeJS    = "js"   // JavaScript verified pixels only
+	TrackerModeBoth  = "both" // Image and JavaScript pixels
+)
+
+// TrackerConfig describes which pixel variants are generated for items
+type TrackerConfig struct {
+	// Mode by default for all zones
+	Mode string `json:"mode" yaml:"mode"`
+
+	// ZoneModes overrides the mode for specific zone IDs
+	ZoneModes map[uint64]string `json:"zone_modes" yaml:"zone_modes"`
+
+	// AllowRequestMode permits to override the mode by `tracker` query parameter
+	AllowRequestMode bool `json:"allow_request_mode" yaml:"allow_request_mode"`
+}
+
+// IsValidTrackerMode returns true if the mode is supported
+func IsValidTrackerMode(mode string) bool {
+	switch mode {
+	case TrackerModeImage, TrackerModeJS, TrackerModeBoth:
+		return true
+	}
+	return false
+}
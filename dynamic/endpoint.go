@@ -6,29 +6,48 @@
 package dynamic
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
 
 	"github.com/demdxx/gocast/v2"
 	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
 
 	"github.com/geniusrabbit/adcorelib/admodels"
 	"github.com/geniusrabbit/adcorelib/admodels/types"
 	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
 	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
 	"github.com/geniusrabbit/adcorelib/eventtraking/events"
 	"github.com/geniusrabbit/adcorelib/httpserver/extensions/endpoint"
+
+	"github.com/geniusrabbit/adstdendpoints/debugaccess"
+	"github.com/geniusrabbit/adstdendpoints/debuginfo"
+	"github.com/geniusrabbit/adstdendpoints/mediaevents"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+	"github.com/geniusrabbit/adstdendpoints/viewability"
 )
 
 // Endpoint is a dynamic endpoint
 type _endpoint struct {
-	urlGen   adtype.URLGenerator
-	metaConf MetaConfig
+	urlGen      adtype.URLGenerator
+	metaConf    MetaConfig
+	debugAccess *debugaccess.Policy
+	trackerConf TrackerConfig
+
+	viewabilityConf *viewability.Config
+	themeConf       *templates.ThemeConfig
+	renderer        templates.Renderer
 }
 
 // New creates new dynamic endpoint
-func New(urlGen adtype.URLGenerator, metaConf MetaConfig) *_endpoint {
-	return &_endpoint{urlGen: urlGen, metaConf: metaConf}
+func New(urlGen adtype.URLGenerator, metaConf MetaConfig, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen, metaConf: metaConf}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // Codename of the endpoint
@@ -42,34 +61,52 @@ func (e _endpoint) Handle(source endpoint.Source, request adtype.BidRequester) (
 		response = bidresponse.NewEmptyResponse(request, nil, nil)
 		_ = e.renderEmpty(request.HTTPRequest(), response)
 	} else {
+		var (
+			debug    = e.debugAccess.Granted(request)
+			timing   *debuginfo.Timing
+			recorder *debuginfo.AuctionRecorder
+		)
+		if debug {
+			timing = debuginfo.NewTiming()
+			recorder = debuginfo.NewAuctionRecorder(request)
+		}
+		timing.Since(debuginfo.StageParse, request.HTTPRequest().Time())
+		stopBid := timing.Start(debuginfo.StageBid)
 		response = source.Bid(request)
-		if err := e.render(request.HTTPRequest(), response); err != nil {
+		stopBid()
+		if err := e.render(request.HTTPRequest(), response, debug, timing, recorder); err != nil {
 			response = adtype.NewErrorResponse(request, err)
 		}
 	}
 	return response
 }
 
-func (e _endpoint) render(ctx *fasthttp.RequestCtx, response adtype.Response) error {
-	resp := Response{Version: "1"}
+func (e _endpoint) render(ctx *fasthttp.RequestCtx, response adtype.Response, debug bool, timing *debuginfo.Timing, recorder *debuginfo.AuctionRecorder) error {
+	var (
+		resp      = Response{Version: "1"}
+		debugInfo map[string]any
+	)
 
-	if response.Request().IsDebug() {
-		headers := map[string]string{}
-		for key, value := range ctx.Request.Header.All() {
-			headers[string(key)] = string(value)
-		}
-		resp.Debug = map[string]any{
+	if debug {
+		debugInfo = map[string]any{
 			"http": map[string]any{
-				"uri":     string(ctx.RequestURI()),
-				"ip":      string(ctx.RemoteIP()),
+				"uri":     e.debugAccess.URI(ctx),
+				"ip":      e.debugAccess.IP(e.debugAccess.ClientIP(ctx)),
 				"method":  string(ctx.Method()),
-				"query":   ctx.QueryArgs().String(),
-				"headers": headers,
+				"query":   e.debugAccess.Query(ctx),
+				"headers": e.debugAccess.Headers(ctx),
 			},
+			"auction": recorder.Auction(response),
 		}
+		resp.Debug = debugInfo
+	}
+
+	if e.themeConf != nil {
+		resp.Theme = e.themeConf.Theme(response.Request())
 	}
 
 	// Process response ad items
+	stopRender := timing.Start(debuginfo.StageRender)
 	for _, ad := range response.Ads() {
 		var (
 			assets       []asset
@@ -79,18 +116,14 @@ func (e _endpoint) render(ctx *fasthttp.RequestCtx, response adtype.Response) er
 		)
 
 		// Generate click URL
+		stopURLs := timing.Start(debuginfo.StageURLs)
 		if !aditm.Format().IsProxy() {
 			url, _ = e.urlGen.ClickURL(aditm, response)
 		}
 
-		trackerBlock = tracker{
-			Impressions: []string{
-				e.noErrorPixelURL(events.Impression, events.StatusSuccess, aditm.Impression(), aditm, response, false),
-			},
-			Views: []string{
-				e.noErrorPixelURL(events.View, events.StatusSuccess, aditm.Impression(), aditm, response, false),
-			},
-		}
+		e.pixelsPrepare(&trackerBlock, events.StatusSuccess, aditm.Impression(), aditm, response)
+		e.mediaEventsPrepare(&trackerBlock, aditm, response)
+		e.viewabilityPrepare(&trackerBlock, aditm)
 
 		// Third-party trackers pixels
 		if item, _ := ad.(adtype.ResponseItem); item != nil {
@@ -102,8 +135,10 @@ func (e _endpoint) render(ctx *fasthttp.RequestCtx, response adtype.Response) er
 				trackerBlock.Impressions = append(trackerBlock.Impressions, links...)
 			}
 		}
+		stopURLs()
 
 		// Process assets if provided
+		stopURLs = timing.Start(debuginfo.StageURLs)
 		if baseAssets := aditm.Assets(); len(baseAssets) > 0 {
 			assets = make([]asset, 0, len(baseAssets))
 			processed := map[string]int{}
@@ -129,19 +164,20 @@ func (e _endpoint) render(ctx *fasthttp.RequestCtx, response adtype.Response) er
 				}
 			}
 		}
+		stopURLs()
 
 		// Add item to response group by impression ID
 		resp.getGroupOrCreate(ad.ImpressionID()).addItem(&item{
 			ID:         ad.ID(),
 			Type:       ad.PriorityFormatType().Name(),
 			URL:        url,
-			Content:    aditm.ContentItemString(adtype.ContentItemContent),
+			Content:    e.itemContent(aditm, response),
 			ContentURL: aditm.ContentItemString(adtype.ContentItemIFrameURL),
 			Fields:     noEmptyFieldsMap(aditm.ContentFields()),
 			Assets:     assets,
 			Tracker:    trackerBlock,
 			Meta:       e.prepareItemMeta(aditm, response),
-			Debug: gocast.IfThenExec(response.Request().IsDebug(),
+			Debug: gocast.IfThenExec(debug && !e.debugAccess.IsRedacted(debugaccess.RedactAdUnit),
 				func() any { return map[string]any{"adUnit": ad} },
 				func() any { return nil }),
 		})
@@ -152,19 +188,17 @@ func (e _endpoint) render(ctx *fasthttp.RequestCtx, response adtype.Response) er
 	for _, imp := range req.Impressions() {
 		group := resp.getGroupOrCreate(imp.ID)
 		if len(group.Items) == 0 {
-			group.CustomTracker = tracker{
-				Impressions: []string{
-					e.noErrorPixelURL(events.Impression, events.StatusCustom, imp, nil, response, false),
-				},
-				Views: []string{
-					e.noErrorPixelURL(events.View, events.StatusCustom, imp, nil, response, false),
-				},
-				Clicks: []string{
-					e.noErrorPixelURL(events.Click, events.StatusCustom, imp, nil, response, false),
-				},
-			}
+			group.CustomTracker = e.emptyTracker(imp, response)
 		}
 	}
+	stopRender()
+
+	if timing != nil {
+		debugInfo["timing"] = timing.Stages()
+		// The encoding is measured by the real write, so it's reported in the header only
+		defer timing.WriteHeader(ctx)
+		defer timing.Start(debuginfo.StageEncode)()
+	}
 
 	// Render response to the client as JSONP
 	format := string(ctx.QueryArgs().Peek("format"))
@@ -227,17 +261,7 @@ func (e _endpoint) renderEmpty(ctx *fasthttp.RequestCtx, response adtype.Respons
 	for _, imp := range req.Impressions() {
 		group := resp.getGroupOrCreate(imp.ID)
 		if len(group.Items) == 0 {
-			group.CustomTracker = tracker{
-				Impressions: []string{
-					e.noErrorPixelURL(events.Impression, events.StatusCustom, imp, nil, response, false),
-				},
-				Views: []string{
-					e.noErrorPixelURL(events.View, events.StatusCustom, imp, nil, response, false),
-				},
-				Clicks: []string{
-					e.noErrorPixelURL(events.Click, events.StatusCustom, imp, nil, response, false),
-				},
-			}
+			group.CustomTracker = e.emptyTracker(imp, response)
 		}
 	}
 
@@ -259,6 +283,95 @@ func (e _endpoint) thumbsPrepare(thumbs []admodels.AdFileAssetThumb) []assetThum
 	return nthumbs
 }
 
+func (e _endpoint) emptyTracker(imp *adtype.Impression, response adtype.Response) tracker {
+	trackerBlock := tracker{
+		Clicks: []string{
+			e.noErrorPixelURL(events.Click, events.StatusCustom, imp, nil, response, false),
+		},
+	}
+	e.pixelsPrepare(&trackerBlock, events.StatusCustom, imp, nil, response)
+	return trackerBlock
+}
+
+// pixelsPrepare fills impression and view pixels according to the tracker mode
+func (e _endpoint) pixelsPrepare(trackerBlock *tracker, status uint8, imp *adtype.Impression, item adtype.ResponseItem, response adtype.Response) {
+	mode := e.trackerMode(response.Request(), imp)
+	if mode != TrackerModeJS {
+		trackerBlock.Impressions = append(trackerBlock.Impressions,
+			e.noErrorPixelURL(events.Impression, status, imp, item, response, false))
+		trackerBlock.Views = append(trackerBlock.Views,
+			e.noErrorPixelURL(events.View, status, imp, item, response, false))
+	}
+	if mode != TrackerModeImage {
+		trackerBlock.ImpressionsJS = append(trackerBlock.ImpressionsJS,
+			e.noErrorPixelURL(events.Impression, status, imp, item, response, true))
+		trackerBlock.ViewsJS = append(trackerBlock.ViewsJS,
+			e.noErrorPixelURL(events.View, status, imp, item, response, true))
+	}
+}
+
+// itemContent returns the HTML content of the item or renders it for proxy items without content
+func (e _endpoint) itemContent(item adtype.ResponseItem, response adtype.Response) string {
+	content := item.ContentItemString(adtype.ContentItemContent)
+	if content != "" || e.renderer == nil || !item.Format().IsProxy() ||
+		item.ContentItemString(adtype.ContentItemIFrameURL) != "" {
+		return content
+	}
+	var buf bytes.Buffer
+	// Pixels are fired by the client from the tracker block of the item
+	if err := e.renderer.RenderItem(&buf, &templates.Params{NoPixels: true}, response, item); err != nil {
+		ctxlogger.Get(response.Context()).Error("render dynamic item content", zap.Error(err))
+		return ""
+	}
+	return buf.String()
+}
+
+// mediaEventsPrepare generates video and rich media event pixels merged with advertiser trackers
+func (e _endpoint) mediaEventsPrepare(trackerBlock *tracker, item adtype.ResponseItem, response adtype.Response) {
+	if !mediaevents.IsMediaItem(item) {
+		return
+	}
+	trackerBlock.Events = make(map[string][]string, len(mediaevents.List))
+	for _, event := range mediaevents.List {
+		links := []string{
+			e.noErrorPixelURL(event, events.StatusSuccess, item.Impression(), item, response, false),
+		}
+		links = append(links, mediaevents.ThirdPartyTrackerLinks(item, event)...)
+		trackerBlock.Events[mediaevents.Name(event)] = links
+	}
+}
+
+// viewabilityPrepare sets the view measurement rule and verification scripts of the item
+func (e _endpoint) viewabilityPrepare(trackerBlock *tracker, item adtype.ResponseItem) {
+	if e.viewabilityConf == nil {
+		return
+	}
+	var codename string
+	if format := item.Format(); format != nil {
+		codename = format.Codename
+	}
+	trackerBlock.Viewability = e.viewabilityConf.ItemRule(item)
+	trackerBlock.Verifications = e.viewabilityConf.VerificationsFor(codename)
+}
+
+// trackerMode returns the pixel mode from the request, zone or default config
+func (e _endpoint) trackerMode(request adtype.BidRequester, imp *adtype.Impression) string {
+	if e.trackerConf.AllowRequestMode && request != nil {
+		if mode := string(request.HTTPRequest().QueryArgs().Peek("tracker")); IsValidTrackerMode(mode) {
+			return mode
+		}
+	}
+	if imp != nil && len(e.trackerConf.ZoneModes) > 0 {
+		if mode := e.trackerConf.ZoneModes[uint64(imp.TargetID())]; IsValidTrackerMode(mode) {
+			return mode
+		}
+	}
+	if IsValidTrackerMode(e.trackerConf.Mode) {
+		return e.trackerConf.Mode
+	}
+	return TrackerModeImage
+}
+
 func (e _endpoint) noErrorPixelURL(event events.Type, status uint8, imp *adtype.Impression, item adtype.ResponseItem, response adtype.Response, js bool) string {
 	if item == nil {
 		if imp == nil {
@@ -0,0 +1,257 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+	"github.com/geniusrabbit/adstdendpoints/mediaevents"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+	"github.com/geniusrabbit/adstdendpoints/viewability"
+)
+
+func handle(t *testing.T, uri string, items []adtype.ResponseItemCommon, opts ...Option) *Response {
+	t.Helper()
+	request := endpointtest.NewRequest(uri)
+	New(endpointtest.URLGen{}, MetaConfig{}, opts...).Handle(&endpointtest.Source{Items: items}, request)
+
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != 200 {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/json" {
+		t.Errorf("content type = %q, want application/json", ct)
+	}
+	var resp Response
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return &resp
+}
+
+func firstItem(t *testing.T, resp *Response) *item {
+	t.Helper()
+	if len(resp.Groups) != 1 || len(resp.Groups[0].Items) != 1 {
+		t.Fatalf("response must have one group with one item: %+v", resp.Groups)
+	}
+	return resp.Groups[0].Items[0]
+}
+
+func nativeItem() []adtype.ResponseItemCommon {
+	return []adtype.ResponseItemCommon{
+		endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Title"}),
+	}
+}
+
+func TestHandleTrackerModes(t *testing.T) {
+	var (
+		img = tracker{
+			Impressions: []string{endpointtest.PixelURL(events.Impression, "ad1"), "https://imp.example.com/ad1"},
+			Views:       []string{endpointtest.PixelURL(events.View, "ad1"), "https://view.example.com/ad1"},
+		}
+		js = tracker{
+			Impressions:   []string{"https://imp.example.com/ad1"},
+			Views:         []string{"https://view.example.com/ad1"},
+			ImpressionsJS: []string{endpointtest.PixelJSURL(events.Impression, "ad1")},
+			ViewsJS:       []string{endpointtest.PixelJSURL(events.View, "ad1")},
+		}
+		both = tracker{
+			Impressions:   img.Impressions,
+			Views:         img.Views,
+			ImpressionsJS: js.ImpressionsJS,
+			ViewsJS:       js.ViewsJS,
+		}
+	)
+	tests := []struct {
+		name  string
+		query string
+		conf  TrackerConfig
+		want  tracker
+	}{
+		{name: "default", want: img},
+		{name: "invalid_mode", conf: TrackerConfig{Mode: "gif"}, want: img},
+		{name: "js", conf: TrackerConfig{Mode: TrackerModeJS}, want: js},
+		{name: "both", conf: TrackerConfig{Mode: TrackerModeBoth}, want: both},
+		{name: "zone", conf: TrackerConfig{ZoneModes: map[uint64]string{0: TrackerModeJS}}, want: js},
+		{name: "request", query: "&tracker=both", conf: TrackerConfig{AllowRequestMode: true}, want: both},
+		{name: "request_not_allowed", query: "&tracker=js", want: img},
+		{name: "request_invalid", query: "&tracker=gif", conf: TrackerConfig{Mode: TrackerModeJS, AllowRequestMode: true}, want: js},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handle(t, "https://ads.example.com/dynamic?zone=1"+tt.query, nativeItem(), WithTrackerConfig(tt.conf))
+			got := firstItem(t, resp).Tracker
+			for _, field := range []struct {
+				name      string
+				got, want []string
+			}{
+				{name: "impressions", got: got.Impressions, want: tt.want.Impressions},
+				{name: "views", got: got.Views, want: tt.want.Views},
+				{name: "impressions_js", got: got.ImpressionsJS, want: tt.want.ImpressionsJS},
+				{name: "views_js", got: got.ViewsJS, want: tt.want.ViewsJS},
+			} {
+				if !slices.Equal(field.got, field.want) {
+					t.Errorf("%s = %v, want %v", field.name, field.got, field.want)
+				}
+			}
+			if !slices.Equal(got.Clicks, []string{"https://clk.example.com/ad1"}) {
+				t.Errorf("clicks = %v", got.Clicks)
+			}
+		})
+	}
+}
+
+func TestHandleEmptyTracker(t *testing.T) {
+	resp := handle(t, "https://ads.example.com/dynamic?zone=1", nil,
+		WithTrackerConfig(TrackerConfig{Mode: TrackerModeJS}))
+	if len(resp.Groups) != 1 || len(resp.Groups[0].Items) != 0 {
+		t.Fatalf("response must have one empty group: %+v", resp.Groups)
+	}
+	tr := resp.Groups[0].CustomTracker
+	if len(tr.Impressions) != 0 || !slices.Equal(tr.ImpressionsJS, []string{endpointtest.PixelJSURL(events.Impression, "")}) {
+		t.Errorf("empty group tracker = %+v, want the JS pixels only", tr)
+	}
+}
+
+func TestHandleMediaEvents(t *testing.T) {
+	video := endpointtest.NewItem("ad1", types.FormatVideoType, map[string]any{
+		mediaevents.ContentItemEventTrackers: map[string]any{
+			"start":    []any{"https://adv.example.com/start"},
+			"complete": []string{"https://adv.example.com/complete"},
+		},
+	})
+	tests := []struct {
+		name string
+		item *endpointtest.Item
+		want map[string][]string
+	}{
+		{
+			name: "video",
+			item: video,
+			want: map[string][]string{
+				"start":         {endpointtest.PixelURL(mediaevents.Start, "ad1"), "https://adv.example.com/start"},
+				"firstQuartile": {endpointtest.PixelURL(mediaevents.FirstQuartile, "ad1")},
+				"complete":      {endpointtest.PixelURL(mediaevents.Complete, "ad1"), "https://adv.example.com/complete"},
+				"close":         {endpointtest.PixelURL(mediaevents.Close, "ad1")},
+			},
+		},
+		{
+			name: "native",
+			item: endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Title"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handle(t, "https://ads.example.com/dynamic?zone=1", []adtype.ResponseItemCommon{tt.item})
+			got := firstItem(t, resp).Tracker.Events
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("events = %v, want none for the non-media item", got)
+				}
+				return
+			}
+			if len(got) != len(mediaevents.List) {
+				t.Errorf("events count = %d, want %d", len(got), len(mediaevents.List))
+			}
+			for name, links := range tt.want {
+				if !slices.Equal(got[name], links) {
+					t.Errorf("event %s = %v, want %v", name, got[name], links)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleViewability(t *testing.T) {
+	conf := viewability.Config{
+		Formats: map[string]viewability.Rule{"test": {MinVisibleRatio: 0.3, MinDurationMs: 500}},
+		Verifications: []viewability.VerificationResource{
+			{Vendor: "all", ScriptURL: "https://v.example.com/all.js"},
+			{Vendor: "other", ScriptURL: "https://v.example.com/other.js", Formats: []string{"other"}},
+		},
+	}
+	tests := []struct {
+		name          string
+		opts          []Option
+		rule          *viewability.Rule
+		verifications []string
+	}{
+		{name: "disabled"},
+		{
+			name:          "format_rule",
+			opts:          []Option{WithViewabilityConfig(conf)},
+			rule:          &viewability.Rule{MinVisibleRatio: 0.3, MinDurationMs: 500},
+			verifications: []string{"all"},
+		},
+		{
+			name: "default_rule",
+			opts: []Option{WithViewabilityConfig(viewability.Config{})},
+			rule: &viewability.DefaultRule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := firstItem(t, handle(t, "https://ads.example.com/dynamic?zone=1", nativeItem(), tt.opts...)).Tracker
+			switch {
+			case tt.rule == nil && got.Viewability != nil:
+				t.Errorf("viewability = %+v, want none", got.Viewability)
+			case tt.rule != nil && (got.Viewability == nil || *got.Viewability != *tt.rule):
+				t.Errorf("viewability = %+v, want %+v", got.Viewability, tt.rule)
+			}
+			var vendors []string
+			for _, v := range got.Verifications {
+				vendors = append(vendors, v.Vendor)
+				if v.APIFramework != "omid" {
+					t.Errorf("verification %s framework = %q, want omid", v.Vendor, v.APIFramework)
+				}
+			}
+			if !slices.Equal(vendors, tt.verifications) {
+				t.Errorf("verifications = %v, want %v", vendors, tt.verifications)
+			}
+		})
+	}
+}
+
+func TestHandleTheme(t *testing.T) {
+	conf := templates.ThemeConfig{
+		Default:           templates.Theme{FontSize: 16},
+		Themes:            map[string]templates.Theme{"dark": {LabelPosition: templates.LabelBottom, Colors: templates.ThemeColors{Background: "#000"}}},
+		AllowRequestTheme: true,
+	}
+	tests := []struct {
+		name     string
+		query    string
+		opts     []Option
+		fontSize int
+		position string
+		bg       string
+	}{
+		{name: "disabled"},
+		{name: "default", opts: []Option{WithThemeConfig(conf)}, fontSize: 16, position: templates.LabelHorizontal, bg: "#fff"},
+		{name: "request", query: "&theme=dark", opts: []Option{WithThemeConfig(conf)},
+			fontSize: templates.DefaultTheme.FontSize, position: templates.LabelBottom, bg: "#000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handle(t, "https://ads.example.com/dynamic?zone=1"+tt.query, nativeItem(), tt.opts...)
+			if tt.fontSize == 0 {
+				if resp.Theme != nil {
+					t.Errorf("theme = %+v, want none", resp.Theme)
+				}
+				return
+			}
+			th := resp.Theme
+			if th == nil {
+				t.Fatal("response must contain the theme")
+			}
+			if th.FontSize != tt.fontSize || th.LabelPosition != tt.position || th.Colors.Background != tt.bg {
+				t.Errorf("theme = %+v, want font size %d, position %q, background %q", th, tt.fontSize, tt.position, tt.bg)
+			}
+		})
+	}
+}
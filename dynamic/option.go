@@ -0,0 +1,45 @@
+package dynamic
+
+import (
+	"github.com/geniusrabbit/adstdendpoints/debugaccess"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+	"github.com/geniusrabbit/adstdendpoints/viewability"
+)
+
+// Option of the dynamic endpoint
+type Option func(e *_endpoint)
+
+// WithDebugAccess sets the policy which grants access to the debug output
+func WithDebugAccess(policy *debugaccess.Policy) Option {
+	return func(e *_endpoint) {
+		e.debugAccess = policy
+	}
+}
+
+// WithTrackerConfig sets the tracker pixel mode configuration
+func WithTrackerConfig(conf TrackerConfig) Option {
+	return func(e *_endpoint) {
+		e.trackerConf = conf
+	}
+}
+
+// WithViewabilityConfig enables viewability rules and verification scripts in item trackers
+func WithViewabilityConfig(conf viewability.Config) Option {
+	return func(e *_endpoint) {
+		e.viewabilityConf = &conf
+	}
+}
+
+// WithThemeConfig emits the theme of the zone in the response for client-side renderers
+func WithThemeConfig(conf templates.ThemeConfig) Option {
+	return func(e *_endpoint) {
+		e.themeConf = &conf
+	}
+}
+
+// WithRenderer sets the renderer of the `content` markup for proxy items without own content
+func WithRenderer(renderer templates.Renderer) Option {
+	return func(e *_endpoint) {
+		e.renderer = renderer
+	}
+}
@@ -1,10 +1,21 @@
 package dynamic
 
+import (
+	"github.com/geniusrabbit/adstdendpoints/templates"
+	"github.com/geniusrabbit/adstdendpoints/viewability"
+)
+
 //easyjson:json
 type tracker struct {
-	Clicks      []string `json:"clicks,omitempty"`
-	Impressions []string `json:"impressions,omitempty"`
-	Views       []string `json:"views,omitempty"`
+	Clicks        []string `json:"clicks,omitempty"`
+	Impressions   []string `json:"impressions,omitempty"`
+	Views         []string `json:"views,omitempty"`
+	ImpressionsJS []string `json:"impressions_js,omitempty"`
+	ViewsJS       []string `json:"views_js,omitempty"`
+
+	Events        map[string][]string                `json:"events,omitempty"`
+	Viewability   *viewability.Rule                  `json:"viewability,omitempty"`
+	Verifications []viewability.VerificationResource `json:"verifications,omitempty"`
 }
 
 type assetThumb struct {
@@ -100,10 +111,11 @@ func (g *group) addItem(i *item) *group {
 //
 //easyjson:json
 type Response struct {
-	Version       string   `json:"version"`
-	CustomTracker tracker  `json:"custom_tracker,omitempty"`
-	Groups        []*group `json:"groups,omitempty"`
-	Debug         any      `json:"debug,omitempty"`
+	Version       string           `json:"version"`
+	CustomTracker tracker          `json:"custom_tracker,omitempty"`
+	Groups        []*group         `json:"groups,omitempty"`
+	Theme         *templates.Theme `json:"theme,omitempty"`
+	Debug         any              `json:"debug,omitempty"`
 }
 
 func (r *Response) getGroupOrCreate(groupID string) *group {
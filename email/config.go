@@ -0,0 +1,52 @@
+package email
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Image serving modes
+const (
+	// ImageRedirect redirects to the CDN URL of the image (default)
+	ImageRedirect = "redirect"
+
+	// ImageProxy fetches the image from the CDN and serves it from the endpoint
+	ImageProxy = "proxy"
+)
+
+// DefaultTTL of the placement
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Config of the email endpoints
+type Config struct {
+	// ImageMode of the image serving: `redirect` or `proxy`
+	ImageMode string `json:"image_mode" yaml:"image_mode"`
+
+	// TTL of the placement, DefaultTTL if empty
+	TTL time.Duration `json:"ttl" yaml:"ttl"`
+
+	// FailoverImageURL is redirected to if there is no ad, the transparent pixel is served if empty
+	FailoverImageURL string `json:"failover_image_url" yaml:"failover_image_url"`
+
+	// FailoverClickURL is redirected to if the placement is unknown
+	FailoverClickURL string `json:"failover_click_url" yaml:"failover_click_url"`
+}
+
+func (conf *Config) ttl() time.Duration {
+	if conf.TTL > 0 {
+		return conf.TTL
+	}
+	return DefaultTTL
+}
+
+// PlacementKey returns the stable key of the placement from the `key` request parameter
+// and the zone, or empty string if the key is not defined
+func PlacementKey(request adtype.BidRequester) string {
+	key := request.HTTPRequest().QueryArgs().Peek("key")
+	if len(key) == 0 {
+		return ""
+	}
+	return strconv.FormatUint(request.TargetID(), 10) + ":" + string(key)
+}
@@ -0,0 +1,205 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package email
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+	"github.com/geniusrabbit/adcorelib/eventtraking/eventstream"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// ErrInvalidImageStatus is returned if the CDN responds with non 200 status in the proxy mode
+var ErrInvalidImageStatus = errors.New("email: invalid image status")
+
+// transparentGIF is the 1x1 pixel served if there is no ad
+var transparentGIF = []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00!\xf9\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")
+
+type _endpoint struct {
+	urlGen adtype.URLGenerator
+	store  Store
+	conf   Config
+	client *fasthttp.Client
+}
+
+// New creates the email endpoints pair which share the placement store
+func New(urlGen adtype.URLGenerator, store Store, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen, store: store}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.client == nil {
+		e.client = &fasthttp.Client{
+			ReadTimeout:         5 * time.Second,
+			WriteTimeout:        5 * time.Second,
+			MaxResponseBodySize: 4 << 20,
+		}
+	}
+	return e
+}
+
+// Image endpoint serves the image of the winning ad and records the impression
+func (e *_endpoint) Image() *imageEndpoint {
+	return &imageEndpoint{e}
+}
+
+// Click endpoint redirects to the ad of the image shown by the same placement key
+func (e *_endpoint) Click() *clickEndpoint {
+	return &clickEndpoint{e}
+}
+
+type imageEndpoint struct {
+	*_endpoint
+}
+
+// Codename of the endpoint
+func (e *imageEndpoint) Codename() string {
+	return "email.image"
+}
+
+// Handle request of the image. The placement shown by the key is served again without the auction.
+func (e *imageEndpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var (
+		ctx = request.HTTPRequest()
+		key = PlacementKey(request)
+	)
+	ctx.Response.Header.Set("Cache-Control", "no-cache, private")
+	if key != "" {
+		if placement := e.placement(request.Context(), key); placement != nil {
+			e.writeImage(request, placement.ImageURL)
+			return nil
+		}
+	}
+
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	var (
+		it       = templates.FirstResponseItem(response)
+		imageURL string
+	)
+	if response.Error() == nil && it != nil {
+		if asset := it.MainAsset(); asset != nil && asset.IsImage() {
+			imageURL = e.urlGen.CDNURL(asset.URL)
+		}
+	}
+	if imageURL == "" {
+		e.writeEmpty(ctx)
+		return response
+	}
+
+	clickURL, _ := e.urlGen.ClickURL(it, response)
+	if key != "" {
+		err := e.store.Set(request.Context(), key, &Placement{ImageURL: imageURL, ClickURL: clickURL}, e.conf.ttl())
+		if err != nil {
+			ctxlogger.Get(request.Context()).Error("email store placement", zap.Error(err))
+		}
+	}
+	e.writeImage(request, imageURL)
+	e.sendImpression(response, it)
+	return response
+}
+
+type clickEndpoint struct {
+	*_endpoint
+}
+
+// Codename of the endpoint
+func (e *clickEndpoint) Codename() string {
+	return "email.click"
+}
+
+// Handle the click and redirect to the click URL of the placement
+func (e *clickEndpoint) Handle(_ adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	ctx := request.HTTPRequest()
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	if key := PlacementKey(request); key != "" {
+		if placement := e.placement(request.Context(), key); placement != nil && placement.ClickURL != "" {
+			ctx.Redirect(placement.ClickURL, http.StatusFound)
+			return nil
+		}
+	}
+	if e.conf.FailoverClickURL != "" {
+		ctx.Response.Header.Set("X-Status-Failover", "1")
+		ctx.Redirect(e.conf.FailoverClickURL, http.StatusFound)
+		return nil
+	}
+	ctx.Error("placement not found", http.StatusNotFound)
+	return nil
+}
+
+func (e *_endpoint) placement(ctx context.Context, key string) *Placement {
+	placement, err := e.store.Get(ctx, key)
+	if err != nil {
+		ctxlogger.Get(ctx).Error("email get placement", zap.Error(err))
+	}
+	return placement
+}
+
+// writeImage redirects to the image or proxies it from the CDN
+func (e *_endpoint) writeImage(request adtype.BidRequester, imageURL string) {
+	ctx := request.HTTPRequest()
+	if e.conf.ImageMode != ImageProxy {
+		ctx.Redirect(imageURL, http.StatusFound)
+		return
+	}
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+	}()
+	req.SetRequestURI(endpointutil.AbsoluteURL(imageURL))
+	err := e.client.Do(req, resp)
+	if err == nil && resp.StatusCode() != http.StatusOK {
+		err = ErrInvalidImageStatus
+	}
+	if err != nil {
+		ctxlogger.Get(request.Context()).Error("email proxy image",
+			zap.String("url", imageURL), zap.Error(err))
+		ctx.Redirect(imageURL, http.StatusFound)
+		return
+	}
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetContentTypeBytes(resp.Header.ContentType())
+	ctx.SetBody(resp.Body())
+}
+
+// writeEmpty redirects to the failover image or serves the transparent pixel
+func (e *_endpoint) writeEmpty(ctx *fasthttp.RequestCtx) {
+	if e.conf.FailoverImageURL != "" {
+		ctx.Response.Header.Set("X-Status-Failover", "1")
+		ctx.Redirect(e.conf.FailoverImageURL, http.StatusFound)
+		return
+	}
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetContentType("image/gif")
+	ctx.SetBody(transparentGIF)
+}
+
+// sendImpression records the impression of the image, email clients can't run the pixels
+func (e *_endpoint) sendImpression(response adtype.Response, it adtype.ResponseItem) {
+	stream := eventstream.StreamFromContext(response.Context())
+	if err := stream.Send(events.Impression, events.StatusSuccess, response, it); err != nil {
+		ctxlogger.Get(response.Context()).Error("send email impression event", zap.Error(err))
+	}
+}
@@ -0,0 +1,89 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+)
+
+func TestHandleImage(t *testing.T) {
+	var (
+		store  = NewMemoryStore()
+		e      = New(endpointtest.URLGen{}, store)
+		image  = &admodels.AdFileAsset{URL: "a.png", Type: types.AdFileAssetImageType}
+		source = &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+			endpointtest.NewItem("ad1", types.FormatBannerType, nil, image),
+		}}
+		request = endpointtest.NewRequest("https://ads.example.com/email/image?zone=1&key=u1")
+	)
+	e.Image().Handle(source, request)
+
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != http.StatusFound {
+		t.Errorf("status = %d, want %d", code, http.StatusFound)
+	}
+	if loc := string(ctx.Response.Header.Peek("Location")); !strings.Contains(loc, "cdn.example.com/a.png") {
+		t.Errorf("Location = %q, want the CDN URL of the image", loc)
+	}
+	if cc := string(ctx.Response.Header.Peek("Cache-Control")); cc != "no-cache, private" {
+		t.Errorf("Cache-Control = %q, want no-cache, private", cc)
+	}
+	if stream := endpointtest.RequestStream(request); len(stream.Events) != 1 || stream.Events[0] != events.Impression {
+		t.Errorf("events = %v, want the impression", stream.Events)
+	}
+
+	placement, _ := store.Get(context.Background(), PlacementKey(request))
+	if placement == nil || placement.ClickURL != endpointtest.ClickURL("ad1") {
+		t.Fatalf("stored placement = %+v, want the click URL of the item", placement)
+	}
+
+	// The image of the same key is served again without the auction
+	request = endpointtest.NewRequest("https://ads.example.com/email/image?zone=1&key=u1")
+	e.Image().Handle(source, request)
+	if source.Bids != 1 {
+		t.Errorf("repeated image must not run the auction, got %d bids", source.Bids)
+	}
+
+	request = endpointtest.NewRequest("https://ads.example.com/email/click?zone=1&key=u1")
+	e.Click().Handle(source, request)
+	ctx = request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != http.StatusFound {
+		t.Errorf("click status = %d, want %d", code, http.StatusFound)
+	}
+	if loc := string(ctx.Response.Header.Peek("Location")); loc != endpointtest.ClickURL("ad1") {
+		t.Errorf("click Location = %q, want %q", loc, endpointtest.ClickURL("ad1"))
+	}
+}
+
+func TestHandleImageEmpty(t *testing.T) {
+	var (
+		e       = New(endpointtest.URLGen{}, NewMemoryStore())
+		request = endpointtest.NewRequest("https://ads.example.com/email/image?zone=1&key=u1")
+	)
+	e.Image().Handle(&endpointtest.Source{}, request)
+
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != http.StatusOK {
+		t.Errorf("status = %d, want %d", code, http.StatusOK)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "image/gif" {
+		t.Errorf("content type = %q, want image/gif", ct)
+	}
+	if stream := endpointtest.RequestStream(request); len(stream.Events) != 0 {
+		t.Errorf("events = %v, want none", stream.Events)
+	}
+
+	request = endpointtest.NewRequest("https://ads.example.com/email/click?zone=1&key=u1")
+	e.Click().Handle(&endpointtest.Source{}, request)
+	if code := request.HTTPRequest().Response.StatusCode(); code != http.StatusNotFound {
+		t.Errorf("click status = %d, want %d", code, http.StatusNotFound)
+	}
+}
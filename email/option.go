@@ -0,0 +1,20 @@
+package email
+
+import "github.com/valyala/fasthttp"
+
+// Option of the email endpoints
+type Option func(e *_endpoint)
+
+// WithConfig sets the endpoints config
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
+
+// WithHTTPClient sets the client which fetches images in the proxy mode
+func WithHTTPClient(client *fasthttp.Client) Option {
+	return func(e *_endpoint) {
+		e.client = client
+	}
+}
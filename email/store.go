@@ -0,0 +1,65 @@
+package email
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Placement of the ad shown in the email by the placement key
+type Placement struct {
+	ImageURL string `json:"image_url"`
+	ClickURL string `json:"click_url"`
+}
+
+// Store of the placements which ties the shown image and the click together
+type Store interface {
+	Get(ctx context.Context, key string) (*Placement, error)
+	Set(ctx context.Context, key string, placement *Placement, ttl time.Duration) error
+}
+
+type memoryItem struct {
+	placement *Placement
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the placements in memory of the single instance
+type MemoryStore struct {
+	mx    sync.RWMutex
+	items map[string]memoryItem
+	sets  int
+}
+
+// NewMemoryStore creates new in-memory placement store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{items: map[string]memoryItem{}}
+}
+
+// Get returns the placement by key or nil if there is no placement or it is expired
+func (s *MemoryStore) Get(_ context.Context, key string) (*Placement, error) {
+	s.mx.RLock()
+	item, ok := s.items[key]
+	s.mx.RUnlock()
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, nil
+	}
+	return item.placement, nil
+}
+
+// Set the placement by key for the ttl
+func (s *MemoryStore) Set(_ context.Context, key string, placement *Placement, ttl time.Duration) error {
+	now := time.Now()
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.items[key] = memoryItem{placement: placement, expiresAt: now.Add(ttl)}
+	if s.sets++; s.sets%1024 == 0 {
+		for k, it := range s.items {
+			if now.After(it.expiresAt) {
+				delete(s.items, k)
+			}
+		}
+	}
+	return nil
+}
+
+var _ Store = (*MemoryStore)(nil)
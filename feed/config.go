@@ -0,0 +1,65 @@
+package feed
+
+import "github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+
+// Feed formats
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+	FormatJSON = "json"
+)
+
+// Default names of the content fields of the entry
+const (
+	DefaultTitleField       = "title"
+	DefaultDescriptionField = "description"
+	DefaultCategory         = "Sponsored"
+)
+
+// Config of the feed endpoint
+type Config struct {
+	// Format of the feed if the request has no `format` parameter, FormatRSS if empty
+	Format string `json:"format" yaml:"format"`
+
+	// Title of the feed
+	Title string `json:"title" yaml:"title"`
+
+	// Link to the site of the feed
+	Link string `json:"link" yaml:"link"`
+
+	// Description of the feed
+	Description string `json:"description" yaml:"description"`
+
+	// TitleField of the native content, DefaultTitleField if empty
+	TitleField string `json:"title_field" yaml:"title_field"`
+
+	// DescriptionField of the native content, DefaultDescriptionField if empty
+	DescriptionField string `json:"description_field" yaml:"description_field"`
+
+	// Category which marks the entries as sponsored, DefaultCategory if empty
+	Category string `json:"category" yaml:"category"`
+}
+
+func (conf *Config) format(format string) string {
+	switch format {
+	case FormatRSS, FormatAtom, FormatJSON:
+		return format
+	}
+	switch conf.Format {
+	case FormatAtom, FormatJSON:
+		return conf.Format
+	}
+	return FormatRSS
+}
+
+func (conf *Config) titleField() string {
+	return endpointutil.OrDefault(conf.TitleField, DefaultTitleField)
+}
+
+func (conf *Config) descriptionField() string {
+	return endpointutil.OrDefault(conf.DescriptionField, DefaultDescriptionField)
+}
+
+func (conf *Config) category() string {
+	return endpointutil.OrDefault(conf.Category, DefaultCategory)
+}
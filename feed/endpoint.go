@@ -0,0 +1,239 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// entry of the feed in the format independent form
+type entry struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	Image       string
+	ImageType   string
+	Content     string
+}
+
+type _endpoint struct {
+	urlGen adtype.URLGenerator
+	conf   Config
+}
+
+// New creates new feed endpoint
+func New(urlGen adtype.URLGenerator, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Codename of the endpoint
+func (e *_endpoint) Codename() string {
+	return "feed"
+}
+
+// Handle request of the feed and return the native ads as entries
+func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	var entries []*entry
+	if response.Error() == nil {
+		for _, it := range templates.ResponseItems(response) {
+			if ent := e.prepareEntry(it, response); ent != nil {
+				entries = append(entries, ent)
+			}
+		}
+	}
+
+	var (
+		ctx = request.HTTPRequest()
+		now = time.Now().UTC()
+		err error
+	)
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	switch format := e.conf.format(string(ctx.QueryArgs().Peek("format"))); format {
+	case FormatAtom:
+		ctx.SetContentType("application/atom+xml; charset=UTF-8")
+		err = e.writeAtom(ctx, request, entries, now)
+	case FormatJSON:
+		ctx.SetContentType("application/feed+json")
+		err = e.writeJSON(ctx, entries, now)
+	default:
+		ctx.SetContentType("application/rss+xml; charset=UTF-8")
+		err = e.writeRSS(ctx, entries, now)
+	}
+	if err != nil {
+		ctxlogger.Get(request.Context()).Error("feed render", zap.Error(err))
+	}
+	return response
+}
+
+// prepareEntry returns the entry of the item or nil if the item has no title or click URL
+func (e *_endpoint) prepareEntry(it adtype.ResponseItem, response adtype.Response) *entry {
+	title := it.ContentItemString(e.conf.titleField())
+	link, _ := e.urlGen.ClickURL(it, response)
+	if title == "" || link == "" {
+		return nil
+	}
+	ent := &entry{
+		ID:          it.ID(),
+		Title:       title,
+		Description: it.ContentItemString(e.conf.descriptionField()),
+		Link:        endpointutil.AbsoluteURL(link),
+	}
+	if asset := it.MainAsset(); asset != nil && asset.IsImage() {
+		ent.Image = endpointutil.AbsoluteURL(e.urlGen.CDNURL(asset.URL))
+		ent.ImageType = asset.ContentType
+		if ent.ImageType == "" {
+			ent.ImageType = "image/jpeg"
+		}
+	}
+	pixel := endpointutil.PixelURL(e.urlGen, events.Impression, it, response)
+	ent.Content = entryContent(ent, append([]string{pixel}, it.ImpressionTrackerLinks()...))
+	return ent
+}
+
+// entryContent returns the HTML content of the entry with the embedded impression pixels,
+// feed readers don't run scripts so the pixels are fired when the entry is shown
+func entryContent(ent *entry, pixels []string) string {
+	var buf strings.Builder
+	if ent.Image != "" {
+		buf.WriteString(`<p><a href="` + html.EscapeString(ent.Link) + `"><img src="` +
+			html.EscapeString(ent.Image) + `" alt="` + html.EscapeString(ent.Title) + `" /></a></p>`)
+	}
+	if ent.Description != "" {
+		buf.WriteString(`<p>` + html.EscapeString(ent.Description) + `</p>`)
+	}
+	for _, pixel := range pixels {
+		if pixel != "" {
+			buf.WriteString(`<img src="` + html.EscapeString(endpointutil.AbsoluteURL(pixel)) +
+				`" width="1" height="1" alt="" style="border:0;width:1px;height:1px" />`)
+		}
+	}
+	return buf.String()
+}
+
+func (e *_endpoint) writeRSS(w io.Writer, entries []*entry, now time.Time) error {
+	doc := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         e.title(),
+			Link:          e.conf.Link,
+			Description:   e.conf.Description,
+			LastBuildDate: now.Format(time.RFC1123Z),
+		},
+	}
+	for _, ent := range entries {
+		item := &rssItem{
+			Title:       ent.Title,
+			Link:        ent.Link,
+			Description: ent.Content,
+			GUID:        rssGUID{IsPermaLink: "false", Value: ent.ID},
+			Category:    e.conf.category(),
+			PubDate:     now.Format(time.RFC1123Z),
+		}
+		if ent.Image != "" {
+			item.Enclosure = &rssEnclosure{URL: ent.Image, Type: ent.ImageType}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+	return writeXML(w, doc)
+}
+
+func (e *_endpoint) writeAtom(w io.Writer, request adtype.BidRequester, entries []*entry, now time.Time) error {
+	updated := now.Format(time.RFC3339)
+	doc := atomFeed{
+		ID:      "urn:adfeed:zone:" + strconv.FormatUint(request.TargetID(), 10),
+		Title:   e.title(),
+		Updated: updated,
+		Author:  atomAuthor{Name: e.title()},
+	}
+	if e.conf.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Href: e.conf.Link})
+	}
+	for _, ent := range entries {
+		item := &atomEntry{
+			ID:       "urn:adfeed:item:" + ent.ID,
+			Title:    ent.Title,
+			Updated:  updated,
+			Summary:  ent.Description,
+			Content:  atomText{Type: "html", Value: ent.Content},
+			Links:    []atomLink{{Href: ent.Link, Rel: "alternate"}},
+			Category: atomCategory{Term: e.conf.category()},
+		}
+		if ent.Image != "" {
+			item.Links = append(item.Links, atomLink{Href: ent.Image, Rel: "enclosure", Type: ent.ImageType})
+		}
+		doc.Entries = append(doc.Entries, item)
+	}
+	return writeXML(w, doc)
+}
+
+func (e *_endpoint) writeJSON(w io.Writer, entries []*entry, now time.Time) error {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       e.title(),
+		HomePageURL: e.conf.Link,
+		Description: e.conf.Description,
+		Items:       []*jsonFeedItem{},
+	}
+	for _, ent := range entries {
+		item := &jsonFeedItem{
+			ID:            ent.ID,
+			URL:           ent.Link,
+			Title:         ent.Title,
+			ContentHTML:   ent.Content,
+			Summary:       ent.Description,
+			Image:         ent.Image,
+			DatePublished: now.Format(time.RFC3339),
+			Tags:          []string{e.conf.category()},
+		}
+		if ent.Image != "" {
+			item.Attachments = []jsonFeedAttachment{{URL: ent.Image, MimeType: ent.ImageType}}
+		}
+		doc.Items = append(doc.Items, item)
+	}
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// title of the feed, the category if the title is not configured
+func (e *_endpoint) title() string {
+	return endpointutil.OrDefault(e.conf.Title, e.conf.category())
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(doc)
+}
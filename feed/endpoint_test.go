@@ -0,0 +1,108 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+)
+
+func testSource() *endpointtest.Source {
+	image := &admodels.AdFileAsset{URL: "main.jpg", Type: types.AdFileAssetImageType}
+	return &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+		endpointtest.NewItem("ad1", types.FormatNativeType,
+			map[string]any{"title": "Title 1", "description": "Description 1"}, image),
+		&bidresponse.ResponseItemBlock{Items: []adtype.ResponseItem{
+			endpointtest.NewItem("ad2", types.FormatNativeType, map[string]any{"title": "Title 2"}),
+			endpointtest.NewItem("ad3", types.FormatNativeType, map[string]any{"description": "No title"}),
+		}},
+	}}
+}
+
+func TestHandleRSS(t *testing.T) {
+	request := endpointtest.NewRequest("https://ads.example.com/feed?zone=1")
+	New(endpointtest.URLGen{}).Handle(testSource(), request)
+
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != 200 {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/rss+xml; charset=UTF-8" {
+		t.Errorf("content type = %q, want application/rss+xml", ct)
+	}
+	if !strings.Contains(string(ctx.Response.Body()), `length="0"`) {
+		t.Errorf("enclosure must have the zero length of the unknown size: %s", ctx.Response.Body())
+	}
+	var doc rss
+	if err := xml.Unmarshal(ctx.Response.Body(), &doc); err != nil {
+		t.Fatalf("invalid RSS: %v", err)
+	}
+	items := doc.Channel.Items
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2 of the single and multiple items", len(items))
+	}
+	for i, id := range []string{"ad1", "ad2"} {
+		it := items[i]
+		if it.GUID.Value != id || it.Link != endpointtest.ClickURL(id) {
+			t.Errorf("item %d = %q %q, want %s with the click URL", i, it.GUID.Value, it.Link, id)
+		}
+		for _, pixel := range []string{
+			"https:" + endpointtest.PixelURL(events.Impression, id),
+			"https://imp.example.com/" + id,
+		} {
+			if !strings.Contains(it.Description, `<img src="`+pixel+`"`) {
+				t.Errorf("content of %s must contain the absolute pixel %q: %s", id, pixel, it.Description)
+			}
+		}
+	}
+	if enc := items[0].Enclosure; enc == nil || enc.URL != "https://cdn.example.com/main.jpg" || enc.Type != "image/jpeg" {
+		t.Errorf("enclosure = %+v, want the absolute image URL", enc)
+	}
+	if items[1].Enclosure != nil {
+		t.Errorf("item without image must have no enclosure: %+v", items[1].Enclosure)
+	}
+}
+
+func TestHandleFormats(t *testing.T) {
+	tests := []struct {
+		format      string
+		contentType string
+	}{
+		{format: FormatAtom, contentType: "application/atom+xml; charset=UTF-8"},
+		{format: FormatJSON, contentType: "application/feed+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			request := endpointtest.NewRequest("https://ads.example.com/feed?zone=1&format=" + tt.format)
+			New(endpointtest.URLGen{}).Handle(testSource(), request)
+
+			ctx := request.HTTPRequest()
+			if code := ctx.Response.StatusCode(); code != 200 {
+				t.Errorf("status = %d, want 200", code)
+			}
+			if ct := string(ctx.Response.Header.ContentType()); ct != tt.contentType {
+				t.Errorf("content type = %q, want %q", ct, tt.contentType)
+			}
+			body := string(ctx.Response.Body())
+			for _, want := range []string{
+				endpointtest.ClickURL("ad1"),
+				endpointtest.ClickURL("ad2"),
+				"https:" + endpointtest.PixelURL(events.Impression, "ad2"),
+			} {
+				if !strings.Contains(body, want) {
+					t.Errorf("feed must contain %q: %s", want, body)
+				}
+			}
+			if strings.Contains(body, "ad3") {
+				t.Errorf("item without title must be skipped: %s", body)
+			}
+		})
+	}
+}
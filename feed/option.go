@@ -0,0 +1,11 @@
+package feed
+
+// Option of the feed endpoint
+type Option func(e *_endpoint)
+
+// WithConfig sets the feed config
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
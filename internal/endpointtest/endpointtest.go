@@ -0,0 +1,199 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+// Package endpointtest contains the fakes of the auction objects
+// to drive the endpoint handlers in the tests
+package endpointtest
+
+import (
+	"context"
+	"net"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+	"github.com/geniusrabbit/adcorelib/eventtraking/eventstream"
+)
+
+// Hosts of the generated URLs
+const (
+	CDNHost   = "//cdn.example.com"
+	PixelHost = "//px.example.com"
+	ClickHost = "https://click.example.com"
+)
+
+// URLGen generates predictable URLs of the items
+type URLGen struct{ adtype.URLGenerator }
+
+// CDNURL returns the protocol-relative URL of the path
+func (URLGen) CDNURL(path string) string { return CDNHost + "/" + path }
+
+// LibURL returns the protocol-relative URL of the path
+func (URLGen) LibURL(path string) string { return CDNHost + "/lib/" + path }
+
+// PixelURL returns the protocol-relative URL of the event of the item
+func (URLGen) PixelURL(event events.Type, _ uint8, it adtype.ResponseItem, _ adtype.Response, js bool) (string, error) {
+	if js {
+		return PixelJSURL(event, it.ID()), nil
+	}
+	return PixelURL(event, it.ID()), nil
+}
+
+// ClickURL returns the click URL of the item
+func (URLGen) ClickURL(it adtype.ResponseItem, _ adtype.Response) (string, error) {
+	return ClickURL(it.ID()), nil
+}
+
+// MustClickURL returns the click URL of the item
+func (g URLGen) MustClickURL(it adtype.ResponseItem, resp adtype.Response) string {
+	link, _ := g.ClickURL(it, resp)
+	return link
+}
+
+// PixelURL of the event generated by URLGen
+func PixelURL(event events.Type, id string) string {
+	return PixelHost + "/" + event.String() + "?id=" + id
+}
+
+// PixelJSURL of the JavaScript event generated by URLGen
+func PixelJSURL(event events.Type, id string) string {
+	return PixelURL(event, id) + "&js=1"
+}
+
+// ClickURL of the item generated by URLGen
+func ClickURL(id string) string {
+	return ClickHost + "/" + id
+}
+
+// Item of the response with the content fields and assets
+type Item struct {
+	bidresponse.ResponseItemBlank
+	Fields    map[string]any
+	AssetList admodels.AdFileAssets
+	Action    string
+}
+
+// NewItem returns the item of the format type with the content fields
+func NewItem(id string, tp types.FormatType, fields map[string]any, assets ...*admodels.AdFileAsset) *Item {
+	imp := &adtype.Impression{ID: "imp1", Target: &adtype.TargetEmpty{}}
+	imp.FormatTypes.Set(tp)
+	return &Item{
+		ResponseItemBlank: bidresponse.ResponseItemBlank{
+			ItemID: id,
+			Imp:    imp,
+			Src:    &adtype.SourceEmpty{},
+			FormatVal: &types.Format{
+				Codename: "test",
+				Types:    *types.NewFormatTypeBitset(tp),
+				Config: &types.FormatConfig{Fields: []types.FormatField{
+					{Name: "title"},
+					{Name: "description"},
+				}},
+			},
+		},
+		Fields:    fields,
+		AssetList: assets,
+		Action:    "https://www.advertiser.example.com/landing",
+	}
+}
+
+// AdID of the item
+func (it *Item) AdID() string { return "ad-" + it.ItemID }
+
+// ActionURL of the advertiser
+func (it *Item) ActionURL() string { return it.Action }
+
+// ContentItem returns the content field by name
+func (it *Item) ContentItem(name string) any { return it.Fields[name] }
+
+// ContentItemString returns the string content field by name
+func (it *Item) ContentItemString(name string) string {
+	s, _ := it.Fields[name].(string)
+	return s
+}
+
+// ContentFields returns all content fields
+func (it *Item) ContentFields() map[string]any { return it.Fields }
+
+// MainAsset of the item
+func (it *Item) MainAsset() *admodels.AdFileAsset { return it.AssetList.Main() }
+
+// Asset by name
+func (it *Item) Asset(name string) *admodels.AdFileAsset { return it.AssetList.Asset(name) }
+
+// Assets of the item
+func (it *Item) Assets() admodels.AdFileAssets { return it.AssetList }
+
+// ImpressionTrackerLinks of the third-party trackers
+func (it *Item) ImpressionTrackerLinks() []string {
+	return []string{"https://imp.example.com/" + it.ItemID}
+}
+
+// ViewTrackerLinks of the third-party trackers
+func (it *Item) ViewTrackerLinks() []string {
+	return []string{"https://view.example.com/" + it.ItemID}
+}
+
+// ClickTrackerLinks of the third-party trackers
+func (it *Item) ClickTrackerLinks() []string {
+	return []string{"https://clk.example.com/" + it.ItemID}
+}
+
+// Source returns the prepared items as the auction result
+type Source struct {
+	adtype.SourceEmpty
+	Items []adtype.ResponseItemCommon
+	Err   error
+	Bids  int
+}
+
+// Bid returns the response with the prepared items
+func (src *Source) Bid(request adtype.BidRequester) adtype.Response {
+	src.Bids++
+	return bidresponse.NewResponse(request, src, src.Items, src.Err)
+}
+
+// ProcessResponse does nothing
+func (src *Source) ProcessResponse(adtype.Response) {}
+
+// Stream records the events sent by the endpoint
+type Stream struct {
+	eventstream.Stream
+	Events []events.Type
+}
+
+// Send records the event type
+func (s *Stream) Send(event events.Type, _ uint8, _ adtype.Response, _ adtype.ResponseItem) error {
+	s.Events = append(s.Events, event)
+	return nil
+}
+
+// NewRequest returns the request of the URI with the single impression
+// and the event stream in the context
+func NewRequest(uri string) *bidrequest.BidRequest {
+	var (
+		req fasthttp.Request
+		ctx = &fasthttp.RequestCtx{}
+	)
+	req.SetRequestURI(uri)
+	ctx.Init(&req, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 80}, nil)
+	return &bidrequest.BidRequest{
+		IDVal:      "req1",
+		Ctx:        eventstream.WithStream(context.Background(), &Stream{}),
+		RequestCtx: ctx,
+		Imps:       []*adtype.Impression{{ID: "imp1", Target: &adtype.TargetEmpty{}}},
+	}
+}
+
+// RequestStream returns the event stream of the request created by NewRequest
+func RequestStream(request adtype.BidRequester) *Stream {
+	stream, _ := eventstream.StreamFromContext(request.Context()).(*Stream)
+	return stream
+}
@@ -0,0 +1,36 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+// Package endpointutil contains the helpers shared by the endpoints
+// which return the ads in the formats of the external clients
+package endpointutil
+
+import (
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+)
+
+// AbsoluteURL adds the scheme to the protocol-relative URL,
+// the clients out of the page (mail, feed readers) require absolute links
+func AbsoluteURL(link string) string {
+	if len(link) > 1 && link[0] == '/' && link[1] == '/' {
+		return "https:" + link
+	}
+	return link
+}
+
+// OrDefault returns the value or the default if the value is empty
+func OrDefault(val, def string) string {
+	if val != "" {
+		return val
+	}
+	return def
+}
+
+// PixelURL returns the success event URL of the item or empty string
+func PixelURL(urlGen adtype.URLGenerator, event events.Type, it adtype.ResponseItem, response adtype.Response) string {
+	url, _ := urlGen.PixelURL(event, events.StatusSuccess, it, response, false)
+	return url
+}
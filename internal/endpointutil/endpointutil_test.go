@@ -0,0 +1,29 @@
+package endpointutil
+
+import "testing"
+
+func TestAbsoluteURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "", want: ""},
+		{in: "/", want: "/"},
+		{in: "/path", want: "/path"},
+		{in: "//cdn.example.com/a.png", want: "https://cdn.example.com/a.png"},
+		{in: "http://cdn.example.com/a.png", want: "http://cdn.example.com/a.png"},
+	}
+	for _, tt := range tests {
+		if got := AbsoluteURL(tt.in); got != tt.want {
+			t.Errorf("AbsoluteURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	if got := OrDefault("", "def"); got != "def" {
+		t.Errorf("OrDefault() of empty value = %q, want def", got)
+	}
+	if got := OrDefault("val", "def"); got != "val" {
+		t.Errorf("OrDefault() = %q, want val", got)
+	}
+}
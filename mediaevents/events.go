@@ -0,0 +1,67 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+// Package mediaevents describes video and rich media interaction events
+// which are tracked in addition to the standard impression, view and click events.
+package mediaevents
+
+import (
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+)
+
+// Video and rich media event types
+const (
+	Start         events.Type = "video.start"
+	FirstQuartile events.Type = "video.q1"
+	Midpoint      events.Type = "video.q2"
+	ThirdQuartile events.Type = "video.q3"
+	Complete      events.Type = "video.complete"
+	Mute          events.Type = "media.mute"
+	Unmute        events.Type = "media.unmute"
+	Pause         events.Type = "media.pause"
+	Resume        events.Type = "media.resume"
+	Skip          events.Type = "media.skip"
+	Close         events.Type = "media.close"
+)
+
+// List of all media events in order of the playback
+var List = []events.Type{
+	Start, FirstQuartile, Midpoint, ThirdQuartile, Complete,
+	Mute, Unmute, Pause, Resume, Skip, Close,
+}
+
+var names = map[events.Type]string{
+	Start:         "start",
+	FirstQuartile: "firstQuartile",
+	Midpoint:      "midpoint",
+	ThirdQuartile: "thirdQuartile",
+	Complete:      "complete",
+	Mute:          "mute",
+	Unmute:        "unmute",
+	Pause:         "pause",
+	Resume:        "resume",
+	Skip:          "skip",
+	Close:         "close",
+}
+
+// Name of the event in VAST notation (start, firstQuartile, midpoint, etc.)
+func Name(event events.Type) string {
+	return names[event]
+}
+
+// IsMediaItem returns true if the item supports media events (video or rich media)
+func IsMediaItem(item adtype.ResponseItem) bool {
+	if format := item.Format(); format != nil {
+		if format.IsVideo() || format.Types.Has(types.FormatBannerHTML5Type) {
+			return true
+		}
+	}
+	if asset := item.MainAsset(); asset != nil && asset.IsVideo() {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package mediaevents
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+)
+
+func TestName(t *testing.T) {
+	want := []string{
+		"start", "firstQuartile", "midpoint", "thirdQuartile", "complete",
+		"mute", "unmute", "pause", "resume", "skip", "close",
+	}
+	if len(List) != len(want) {
+		t.Fatalf("events count = %d, want %d", len(List), len(want))
+	}
+	for i, event := range List {
+		if got := Name(event); got != want[i] {
+			t.Errorf("Name(%s) = %q, want %q", event, got, want[i])
+		}
+	}
+	if got := Name(events.Impression); got != "" {
+		t.Errorf("Name() of the non-media event = %q, want empty", got)
+	}
+}
+
+type testItem struct {
+	bidresponse.ResponseItemBlank
+	fields map[string]any
+	asset  *admodels.AdFileAsset
+}
+
+func (it *testItem) ContentItem(name string) any             { return it.fields[name] }
+func (it *testItem) MainAsset() *admodels.AdFileAsset        { return it.asset }
+func (it *testItem) Asset(name string) *admodels.AdFileAsset { return nil }
+
+type accessorItem struct {
+	testItem
+}
+
+func (it *accessorItem) EventTrackerLinks(event events.Type) []string {
+	return []string{"https://acc.example.com/" + Name(event)}
+}
+
+func newItem(tp types.FormatType, asset *admodels.AdFileAsset, fields map[string]any) *testItem {
+	return &testItem{
+		ResponseItemBlank: bidresponse.ResponseItemBlank{
+			FormatVal: &types.Format{Types: *types.NewFormatTypeBitset(tp)},
+		},
+		fields: fields,
+		asset:  asset,
+	}
+}
+
+func TestIsMediaItem(t *testing.T) {
+	video := &admodels.AdFileAsset{URL: "v.mp4", Type: types.AdFileAssetVideoType}
+	tests := []struct {
+		name string
+		item *testItem
+		want bool
+	}{
+		{name: "video_format", item: newItem(types.FormatVideoType, nil, nil), want: true},
+		{name: "html5_format", item: newItem(types.FormatBannerHTML5Type, nil, nil), want: true},
+		{name: "video_asset", item: newItem(types.FormatNativeType, video, nil), want: true},
+		{name: "native", item: newItem(types.FormatNativeType, nil, nil), want: false},
+		{name: "banner", item: newItem(types.FormatBannerType, &admodels.AdFileAsset{Type: types.AdFileAssetImageType}, nil), want: false},
+	}
+	for _, tt := range tests {
+		if got := IsMediaItem(tt.item); got != tt.want {
+			t.Errorf("%s: IsMediaItem() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThirdPartyTrackerLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		item  adtype.ResponseItem
+		event events.Type
+		want  []string
+	}{
+		{
+			name:  "string_slices",
+			item:  newItem(types.FormatVideoType, nil, map[string]any{ContentItemEventTrackers: map[string][]string{"start": {"https://a.example.com/s"}}}),
+			event: Start,
+			want:  []string{"https://a.example.com/s"},
+		},
+		{
+			name:  "any_values",
+			item:  newItem(types.FormatVideoType, nil, map[string]any{ContentItemEventTrackers: map[string]any{"midpoint": []any{"https://a.example.com/m"}}}),
+			event: Midpoint,
+			want:  []string{"https://a.example.com/m"},
+		},
+		{
+			name:  "other_event",
+			item:  newItem(types.FormatVideoType, nil, map[string]any{ContentItemEventTrackers: map[string][]string{"start": {"https://a.example.com/s"}}}),
+			event: Complete,
+		},
+		{
+			name:  "no_trackers",
+			item:  newItem(types.FormatVideoType, nil, nil),
+			event: Start,
+		},
+		{
+			name:  "accessor",
+			item:  &accessorItem{*newItem(types.FormatVideoType, nil, nil)},
+			event: Skip,
+			want:  []string{"https://acc.example.com/skip"},
+		},
+	}
+	for _, tt := range tests {
+		if got := ThirdPartyTrackerLinks(tt.item, tt.event); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: ThirdPartyTrackerLinks() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
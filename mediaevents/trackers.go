@@ -0,0 +1,30 @@
+package mediaevents
+
+import (
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+)
+
+// ContentItemEventTrackers is the content item with advertiser event trackers
+// in format `{"start": ["https://..."], "complete": ["https://..."]}`
+const ContentItemEventTrackers = "event_trackers"
+
+type eventTrackerLinksAccessor interface {
+	EventTrackerLinks(event events.Type) []string
+}
+
+// ThirdPartyTrackerLinks returns advertiser-supplied tracking URLs for the event
+func ThirdPartyTrackerLinks(item adtype.ResponseItem, event events.Type) []string {
+	if acc, ok := item.(eventTrackerLinksAccessor); ok {
+		return acc.EventTrackerLinks(event)
+	}
+	switch trackers := item.ContentItem(ContentItemEventTrackers).(type) {
+	case map[string][]string:
+		return trackers[Name(event)]
+	case map[string]any:
+		return gocast.AnySlice[string](trackers[Name(event)])
+	}
+	return nil
+}
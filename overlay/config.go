@@ -0,0 +1,34 @@
+package overlay
+
+import (
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Config of the overlay endpoint
+type Config struct {
+	// Default unit of all zones
+	Default templates.Overlay `json:"default" yaml:"default"`
+
+	// Zones overrides the unit by zone ID
+	Zones map[uint64]templates.Overlay `json:"zones" yaml:"zones"`
+
+	// AllowRequestType selects the unit type by the `type` request parameter
+	AllowRequestType bool `json:"allow_request_type" yaml:"allow_request_type"`
+}
+
+// Unit returns the overlay unit of the request
+func (conf *Config) Unit(request adtype.BidRequester) *templates.Overlay {
+	unit, ok := conf.Zones[request.TargetID()]
+	if !ok {
+		unit = conf.Default
+	}
+	if conf.AllowRequestType {
+		switch tp := string(request.HTTPRequest().QueryArgs().Peek("type")); tp {
+		case templates.OverlayInterstitial, templates.OverlaySticky:
+			unit.Type = tp
+		}
+	}
+	return unit.WithDefaults()
+}
@@ -0,0 +1,74 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package overlay
+
+import (
+	"bytes"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Close is sent when the unit is closed by the button or auto-close
+const Close events.Type = "overlay.close"
+
+type _endpoint struct {
+	urlGen   adtype.URLGenerator
+	conf     Config
+	renderer templates.Renderer
+}
+
+// New creates new overlay endpoint of interstitial and sticky units
+func New(urlGen adtype.URLGenerator, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.renderer == nil {
+		e.renderer = templates.NewQTPLRenderer(urlGen, false)
+	}
+	return e
+}
+
+// Codename of the endpoint
+func (e *_endpoint) Codename() string {
+	return "overlay"
+}
+
+// Handle request of the overlay unit and return the script which shows it on the page
+func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	ctx := request.HTTPRequest()
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetContentType("application/javascript")
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+
+	it := templates.FirstResponseItem(response)
+	if response.Error() != nil || it == nil {
+		return response
+	}
+	var doc bytes.Buffer
+	if err := e.renderer.RenderBanner(&doc, nil, response); err != nil {
+		ctxlogger.Get(request.Context()).Error("overlay render", zap.Error(err))
+		return response
+	}
+	closeURL, _ := e.urlGen.PixelURL(Close, events.StatusSuccess, it, response, false)
+	templates.WriteAdRenderOverlayScript(ctx, e.conf.Unit(request), request.TargetID(), doc.String(), closeURL)
+	return response
+}
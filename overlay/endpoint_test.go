@@ -0,0 +1,86 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+)
+
+func TestHandle(t *testing.T) {
+	var (
+		e       = New(endpointtest.URLGen{}, WithConfig(Config{AllowRequestType: true}))
+		request = endpointtest.NewRequest("https://ads.example.com/overlay?zone=1&type=sticky")
+		source  = &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+			endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Overlay title"}),
+		}}
+	)
+	response := e.Handle(source, request)
+	if len(response.Ads()) != 1 {
+		t.Fatalf("Handle() returned %d ads, want 1", len(response.Ads()))
+	}
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != 200 {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/javascript" {
+		t.Errorf("content type = %q, want application/javascript", ct)
+	}
+	if cc := string(ctx.Response.Header.Peek("Cache-Control")); cc != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", cc)
+	}
+	body := string(ctx.Response.Body())
+	if closeURL := endpointtest.PixelURL(Close, "ad1"); !strings.Contains(body, closeURL) {
+		t.Errorf("script must contain the close pixel %q: %s", closeURL, body)
+	}
+	if !strings.Contains(body, "Overlay title") {
+		t.Errorf("script must contain the ad document: %s", body)
+	}
+}
+
+func TestHandleEmpty(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *bidrequest.BidRequest
+		source  *endpointtest.Source
+	}{
+		{
+			name:    "no_ads",
+			request: endpointtest.NewRequest("https://ads.example.com/overlay?zone=1"),
+			source:  &endpointtest.Source{},
+		},
+		{
+			name: "robot",
+			request: func() *bidrequest.BidRequest {
+				req := endpointtest.NewRequest("https://ads.example.com/overlay?zone=1")
+				req.StateFlags |= bidrequest.BidRequestFlagBot
+				return req
+			}(),
+			source: &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+				endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Overlay title"}),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := New(endpointtest.URLGen{}).Handle(tt.source, tt.request)
+			if len(response.Ads()) != 0 {
+				t.Errorf("Handle() returned %d ads, want 0", len(response.Ads()))
+			}
+			ctx := tt.request.HTTPRequest()
+			if code := ctx.Response.StatusCode(); code != 200 {
+				t.Errorf("status = %d, want 200", code)
+			}
+			if ct := string(ctx.Response.Header.ContentType()); ct != "application/javascript" {
+				t.Errorf("content type = %q, want application/javascript", ct)
+			}
+			if body := ctx.Response.Body(); len(body) != 0 {
+				t.Errorf("empty response must have no script: %s", body)
+			}
+		})
+	}
+}
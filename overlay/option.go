@@ -0,0 +1,20 @@
+package overlay
+
+import "github.com/geniusrabbit/adstdendpoints/templates"
+
+// Option of the overlay endpoint
+type Option func(e *_endpoint)
+
+// WithConfig sets the units configuration
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
+
+// WithRenderer sets the renderer of the ad document inside the unit
+func WithRenderer(renderer templates.Renderer) Option {
+	return func(e *_endpoint) {
+		e.renderer = renderer
+	}
+}
@@ -0,0 +1,66 @@
+package popunder
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// DefaultDirectURL of the direct endpoint opened by the tag
+const DefaultDirectURL = "//{domain}/direct?zone={zone}"
+
+// Config of the popunder tag endpoint
+type Config struct {
+	// Default tag of all zones
+	Default templates.Popunder `json:"default" yaml:"default"`
+
+	// Zones overrides the tag by zone ID
+	Zones map[uint64]templates.Popunder `json:"zones" yaml:"zones"`
+
+	// DirectURL template of the opened URL with `{domain}`, `{zone}` and `{rnd}` placeholders.
+	// Use the neutral path alias of the direct endpoint to avoid ad blocker filters
+	DirectURL string `json:"direct_url" yaml:"direct_url"`
+
+	// ForwardParams of the tag request added to the direct URL, like subids
+	ForwardParams []string `json:"forward_params" yaml:"forward_params"`
+}
+
+// Tag returns the tag configuration of the request
+func (conf *Config) Tag(request adtype.BidRequester) *templates.Popunder {
+	tag, ok := conf.Zones[request.TargetID()]
+	if !ok {
+		tag = conf.Default
+	}
+	return tag.WithDefaults()
+}
+
+// URL returns the direct URL of the request
+func (conf *Config) URL(request adtype.BidRequester, rnd string) string {
+	tpl := conf.DirectURL
+	if tpl == "" {
+		tpl = DefaultDirectURL
+	}
+	link := strings.NewReplacer(
+		"{domain}", request.ServiceDomain(),
+		"{zone}", strconv.FormatUint(request.TargetID(), 10),
+		"{rnd}", rnd,
+	).Replace(tpl)
+	query := request.HTTPRequest().QueryArgs()
+	for _, name := range conf.ForwardParams {
+		value := query.Peek(name)
+		if len(value) == 0 {
+			continue
+		}
+		if strings.Contains(link, "?") {
+			link += "&"
+		} else {
+			link += "?"
+		}
+		link += url.QueryEscape(name) + "=" + url.QueryEscape(string(value))
+	}
+	return link
+}
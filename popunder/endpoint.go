@@ -0,0 +1,89 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package popunder
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Tag event types
+const (
+	// TagLoad is sent when the tag is loaded on the page
+	TagLoad events.Type = "tag.load"
+
+	// TagTrigger is sent when the tag opens the direct URL
+	TagTrigger events.Type = "tag.trigger"
+)
+
+type _endpoint struct {
+	urlGen adtype.URLGenerator
+	conf   Config
+}
+
+// New creates new popunder tag endpoint
+func New(urlGen adtype.URLGenerator, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Codename of the endpoint
+func (e *_endpoint) Codename() string {
+	return "popunder"
+}
+
+// Handle request of the tag and return the script which opens the direct endpoint.
+// The auction is run by the direct endpoint when the tag is triggered.
+func (e *_endpoint) Handle(_ adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	ctx := request.HTTPRequest()
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetContentType("application/javascript")
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	if request.IsRobot() {
+		return nil
+	}
+	response := bidresponse.NewEmptyResponse(request, nil, nil)
+	templates.WriteAdRenderPopunderScript(ctx,
+		e.conf.Tag(request),
+		request.TargetID(),
+		e.conf.URL(request, strconv.FormatInt(time.Now().UnixNano(), 36)),
+		e.pixelURL(TagLoad, request, response),
+		e.pixelURL(TagTrigger, request, response),
+	)
+	return nil
+}
+
+func (e *_endpoint) pixelURL(event events.Type, request adtype.BidRequester, response adtype.Response) string {
+	var (
+		imps    = request.Impressions()
+		formats = request.Formats().List()
+		item    = &bidresponse.ResponseItemBlank{
+			Imp: gocast.IfThenExec(len(imps) > 0,
+				func() *adtype.Impression { return imps[0] },
+				func() *adtype.Impression { return &adtype.Impression{Target: &adtype.TargetEmpty{}} }),
+			Src: &adtype.SourceEmpty{},
+			FormatVal: gocast.IfThenExec(len(formats) > 0,
+				func() *types.Format { return formats[0] },
+				func() *types.Format { return &types.Format{} }),
+		}
+	)
+	url, _ := e.urlGen.PixelURL(event, events.StatusCustom, item, response, false)
+	return url
+}
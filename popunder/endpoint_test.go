@@ -0,0 +1,69 @@
+package popunder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+)
+
+func TestHandle(t *testing.T) {
+	var (
+		e = New(endpointtest.URLGen{}, WithConfig(Config{
+			DirectURL:     "//{domain}/go?zone={zone}",
+			ForwardParams: []string{"subid"},
+		}))
+		request = endpointtest.NewRequest("https://ads.example.com/popunder?zone=1&subid=s1")
+		source  = &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+			endpointtest.NewItem("ad1", types.FormatDirectType, nil),
+		}}
+	)
+	if response := e.Handle(source, request); response != nil {
+		t.Errorf("Handle() = %v, want nil response of the tag", response)
+	}
+	if source.Bids != 0 {
+		t.Errorf("tag must not run the auction, got %d bids", source.Bids)
+	}
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != 200 {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/javascript" {
+		t.Errorf("content type = %q, want application/javascript", ct)
+	}
+	if cc := string(ctx.Response.Header.Peek("Cache-Control")); cc != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", cc)
+	}
+	body := string(ctx.Response.Body())
+	for _, want := range []string{
+		"//ads.example.com/go?zone=0&subid=s1",
+		endpointtest.PixelURL(TagLoad, ""),
+		endpointtest.PixelURL(TagTrigger, ""),
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("script must contain %q: %s", want, body)
+		}
+	}
+}
+
+func TestHandleRobot(t *testing.T) {
+	var (
+		request = endpointtest.NewRequest("https://ads.example.com/popunder?zone=1")
+		source  = &endpointtest.Source{}
+	)
+	request.StateFlags |= bidrequest.BidRequestFlagBot
+	if response := New(endpointtest.URLGen{}).Handle(source, request); response != nil {
+		t.Errorf("Handle() = %v, want nil", response)
+	}
+	ctx := request.HTTPRequest()
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/javascript" {
+		t.Errorf("content type = %q, want application/javascript", ct)
+	}
+	if body := ctx.Response.Body(); len(body) != 0 {
+		t.Errorf("robot must get no script: %s", body)
+	}
+}
@@ -0,0 +1,11 @@
+package popunder
+
+// Option of the popunder tag endpoint
+type Option func(e *_endpoint)
+
+// WithConfig sets the tags configuration
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
@@ -0,0 +1,102 @@
+package proxy
+
+import (
+	"strings"
+
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Rendering modes of the proxy endpoint
+const (
+	// ModeClient renders the loader which requests the ad by EmbeddedAd library (default)
+	ModeClient = "client"
+
+	// ModeServer runs the auction and renders the winning item on the server side
+	ModeServer = "server"
+)
+
+// CSPNoncePlaceholder is replaced by the nonce of the response in the CSP policy
+const CSPNoncePlaceholder = "{nonce}"
+
+// DefaultCSP allows only nonce'd inline scripts and styles and the scripts loaded by them
+const DefaultCSP = "default-src 'none'; " +
+	"script-src 'nonce-{nonce}' 'strict-dynamic' https: http:; " +
+	"style-src 'nonce-{nonce}'; " +
+	"img-src * data:; media-src *; font-src *; connect-src *; frame-src *; " +
+	"base-uri 'none'"
+
+// Config of the proxy endpoint
+type Config struct {
+	// Mode of the rendering: `client` or `server`
+	Mode string `json:"mode" yaml:"mode"`
+
+	// ClientFallback renders the client-side loader if there is no ads in server mode
+	ClientFallback bool `json:"client_fallback" yaml:"client_fallback"`
+
+	// Renderer of the documents, the package level templates configuration is used if nil
+	Renderer templates.Renderer `json:"-" yaml:"-"`
+
+	// CSP policy of the rendered documents, the `{nonce}` placeholder is replaced
+	// by the random nonce of the response. The header is not sent if empty
+	CSP string `json:"csp" yaml:"csp"`
+
+	// SafeFrame renders the IAB SafeFrame API wrapper in all documents,
+	// otherwise it is enabled by the `sf=1` request parameter
+	SafeFrame bool `json:"safeframe" yaml:"safeframe"`
+
+	// MRAID renders the in-app documents with `mraid.js` for all requests,
+	// otherwise it is enabled by the `mraid=1` request parameter
+	MRAID bool `json:"mraid" yaml:"mraid"`
+}
+
+// cspHeader returns the CSP header value for the nonce
+func (conf *Config) cspHeader(nonce string) string {
+	return strings.ReplaceAll(conf.CSP, CSPNoncePlaceholder, nonce)
+}
+
+// Option of the proxy endpoint
+type Option func(conf *Config)
+
+// WithConfig sets the whole endpoint config
+func WithConfig(conf Config) Option {
+	return func(c *Config) {
+		*c = conf
+	}
+}
+
+// WithRenderer sets the renderer of the documents
+func WithRenderer(renderer templates.Renderer) Option {
+	return func(c *Config) {
+		c.Renderer = renderer
+	}
+}
+
+// WithCSP sets the Content-Security-Policy of the rendered documents, use DefaultCSP
+// or the custom policy with the `{nonce}` placeholder
+func WithCSP(policy string) Option {
+	return func(c *Config) {
+		c.CSP = policy
+	}
+}
+
+// WithSafeFrame renders the IAB SafeFrame API wrapper in all documents
+func WithSafeFrame() Option {
+	return func(c *Config) {
+		c.SafeFrame = true
+	}
+}
+
+// WithMRAID renders the in-app documents with `mraid.js` for all requests
+func WithMRAID() Option {
+	return func(c *Config) {
+		c.MRAID = true
+	}
+}
+
+// WithServerMode enables the server-side rendering of the winning item
+func WithServerMode(clientFallback bool) Option {
+	return func(c *Config) {
+		c.Mode = ModeServer
+		c.ClientFallback = clientFallback
+	}
+}
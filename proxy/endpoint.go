@@ -1,30 +1,109 @@
-//go:build htmltemplates
-// +build htmltemplates
-
 //
-// @project GeniusRabbit adstdendpoints 2022
-// @author Dmitry Ponomarev <demdxx@gmail.com> 2022
+// @project GeniusRabbit adstdendpoints 2022, 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2022, 2025
 //
 
 package proxy
 
 import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
 	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
 
 	"github.com/geniusrabbit/adstdendpoints"
 	"github.com/geniusrabbit/adstdendpoints/templates"
 )
 
-type _endpoint struct{}
+// ErrRendererUnavailable is returned if neither the renderer nor templates.URLGen is configured
+var ErrRendererUnavailable = errors.New("proxy: renderer unavailable, no renderer or templates.URLGen configured")
 
-func New() *_endpoint { return &_endpoint{} }
+type _endpoint struct {
+	conf     Config
+	renderer templates.Renderer
+}
+
+// New creates new proxy endpoint. The package level templates configuration
+// is used if there is no renderer, so templates.URLGen must be set before.
+func New(opts ...Option) (*_endpoint, error) {
+	e := &_endpoint{conf: Config{Mode: ModeClient}}
+	for _, opt := range opts {
+		opt(&e.conf)
+	}
+	if e.renderer = e.conf.Renderer; e.renderer == nil {
+		e.renderer = templates.DefaultRenderer()
+	}
+	if e.renderer == nil {
+		return nil, ErrRendererUnavailable
+	}
+	return e, nil
+}
 
 func (e *_endpoint) Codename() string {
 	return "proxy"
 }
 
 func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	params, err := e.params(request)
+	if err != nil {
+		ctxlogger.Get(request.Context()).Error("proxy csp nonce", zap.Error(err))
+		request.HTTPRequest().Error(err.Error(), http.StatusInternalServerError)
+		return adtype.NewErrorResponse(request, err)
+	}
 	request.HTTPRequest().SetContentType("text/html; charset=UTF-8")
-	templates.WriteAdRenderDinamicProxyBanner(request.HTTPRequest(), request)
-	return nil
+	if e.conf.Mode != ModeServer {
+		e.logRenderError(request, e.renderer.RenderLoader(request.HTTPRequest(), params, request))
+		return nil
+	}
+	return e.handleServer(e.renderer, params, source, request)
+}
+
+// params of the rendering, generates the nonce and sets the CSP header if configured
+func (e *_endpoint) params(request adtype.BidRequester) (*templates.Params, error) {
+	query := request.HTTPRequest().QueryArgs()
+	params := &templates.Params{
+		SafeFrame: e.conf.SafeFrame || string(query.Peek("sf")) == "1",
+		MRAID:     e.conf.MRAID || string(query.Peek("mraid")) == "1",
+	}
+	if e.conf.CSP == "" {
+		return params, nil
+	}
+	nonce, err := templates.NewNonce()
+	if err != nil {
+		return nil, err
+	}
+	request.HTTPRequest().Response.Header.Set("Content-Security-Policy", e.conf.cspHeader(nonce))
+	params.Nonce = nonce
+	return params, nil
+}
+
+// handleServer runs the auction and renders the winning item directly
+func (e *_endpoint) handleServer(renderer templates.Renderer, params *templates.Params, source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	var err error
+	switch {
+	case response.Error() == nil && response.Count() > 0:
+		err = renderer.RenderBanner(request.HTTPRequest(), params, response)
+	case e.conf.ClientFallback && !request.IsRobot():
+		err = renderer.RenderLoader(request.HTTPRequest(), params, request)
+	default:
+		err = renderer.RenderEmpty(request.HTTPRequest(), params)
+	}
+	e.logRenderError(request, err)
+	return response
+}
+
+func (e *_endpoint) logRenderError(request adtype.BidRequester, err error) {
+	if err != nil {
+		ctxlogger.Get(request.Context()).Error("proxy render", zap.Error(err))
+	}
 }
@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+func TestNewRenderer(t *testing.T) {
+	if _, err := New(); !errors.Is(err, ErrRendererUnavailable) {
+		t.Errorf("New() without renderer error = %v, want %v", err, ErrRendererUnavailable)
+	}
+	renderer := templates.NewQTPLRenderer(nil, false)
+	e, err := New(WithRenderer(renderer))
+	if err != nil {
+		t.Fatalf("New() with renderer error = %v", err)
+	}
+	if e.renderer != renderer {
+		t.Error("New() must use the configured renderer")
+	}
+}
+
+func TestHandleServerAssetURL(t *testing.T) {
+	var (
+		image = &admodels.AdFileAsset{URL: "banner.png", Type: types.AdFileAssetImageType}
+		video = &admodels.AdFileAsset{URL: "video.mp4", Type: types.AdFileAssetVideoType, Thumbs: []admodels.AdFileAssetThumb{
+			{URL: "poster.jpg", Type: types.AdFileAssetImageType},
+			{URL: "video.webm", Type: types.AdFileAssetVideoType},
+		}}
+	)
+	tests := []struct {
+		name string
+		item adtype.ResponseItemCommon
+		want []string
+	}{
+		{
+			name: "banner",
+			item: endpointtest.NewItem("ad1", types.FormatBannerType, nil, image),
+			want: []string{`src="` + endpointtest.CDNHost + `/banner.png"`},
+		},
+		{
+			name: "video",
+			item: endpointtest.NewItem("ad1", types.FormatVideoType, nil, video),
+			want: []string{
+				`poster="` + endpointtest.CDNHost + `/poster.jpg"`,
+				`src="` + endpointtest.CDNHost + `/video.mp4"`,
+				`src="` + endpointtest.CDNHost + `/video.webm"`,
+			},
+		},
+		{
+			name: "native_image",
+			item: endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Title"}, image),
+			want: []string{
+				`_qPixel.src = '` + endpointtest.CDNHost + `/banner.png'`,
+				`url('` + endpointtest.CDNHost + `/banner.png')`,
+			},
+		},
+		{
+			name: "native_video",
+			item: endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Title"}, video),
+			want: []string{
+				`src="` + endpointtest.CDNHost + `/video.mp4"`,
+				`src="` + endpointtest.CDNHost + `/video.webm"`,
+			},
+		},
+		{
+			name: "widget",
+			item: &bidresponse.ResponseItemBlock{Items: []adtype.ResponseItem{
+				endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Title 1"}, image),
+				endpointtest.NewItem("ad2", types.FormatNativeType, map[string]any{"title": "Title 2"}),
+			}},
+			want: []string{`url('` + endpointtest.CDNHost + `/banner.png')`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(WithRenderer(templates.NewQTPLRenderer(endpointtest.URLGen{}, false)), WithServerMode(false))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			request := endpointtest.NewRequest("https://ads.example.com/proxy?zone=1")
+			e.Handle(&endpointtest.Source{Items: []adtype.ResponseItemCommon{tt.item}}, request)
+
+			body := string(request.HTTPRequest().Response.Body())
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("document must contain the CDN URL %s: %s", want, body)
+				}
+			}
+		})
+	}
+}
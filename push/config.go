@@ -0,0 +1,65 @@
+package push
+
+import (
+	"time"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+)
+
+// Default names of the content fields and assets of the payload
+const (
+	DefaultTitleField = "title"
+	DefaultBodyField  = "description"
+	DefaultIconAsset  = "icon"
+	DefaultBadgeAsset = "badge"
+)
+
+// DefaultActionFields of the notification buttons
+var DefaultActionFields = []string{"cta"}
+
+// Config of the push endpoint
+type Config struct {
+	// TitleField of the native content, DefaultTitleField if empty
+	TitleField string `json:"title_field" yaml:"title_field"`
+
+	// BodyField of the native content, DefaultBodyField if empty
+	BodyField string `json:"body_field" yaml:"body_field"`
+
+	// IconAsset name of the notification icon, DefaultIconAsset if empty
+	IconAsset string `json:"icon_asset" yaml:"icon_asset"`
+
+	// BadgeAsset name of the monochrome status bar badge, DefaultBadgeAsset if empty
+	BadgeAsset string `json:"badge_asset" yaml:"badge_asset"`
+
+	// ActionFields of the content which become action buttons, DefaultActionFields if empty
+	ActionFields []string `json:"action_fields" yaml:"action_fields"`
+
+	// TTL of the notification delivery, unlimited if empty
+	TTL time.Duration `json:"ttl" yaml:"ttl"`
+
+	// RequireInteraction keeps the notification until the user clicks or closes it
+	RequireInteraction bool `json:"require_interaction" yaml:"require_interaction"`
+}
+
+func (conf *Config) titleField() string {
+	return endpointutil.OrDefault(conf.TitleField, DefaultTitleField)
+}
+
+func (conf *Config) bodyField() string {
+	return endpointutil.OrDefault(conf.BodyField, DefaultBodyField)
+}
+
+func (conf *Config) iconAsset() string {
+	return endpointutil.OrDefault(conf.IconAsset, DefaultIconAsset)
+}
+
+func (conf *Config) badgeAsset() string {
+	return endpointutil.OrDefault(conf.BadgeAsset, DefaultBadgeAsset)
+}
+
+func (conf *Config) actionFields() []string {
+	if len(conf.ActionFields) > 0 {
+		return conf.ActionFields
+	}
+	return DefaultActionFields
+}
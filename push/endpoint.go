@@ -0,0 +1,121 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package push
+
+import (
+	"encoding/json"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Push event types
+const (
+	// Delivery is sent when the service worker receives the payload
+	Delivery events.Type = "push.delivery"
+
+	// Close is sent when the notification is closed without the click
+	Close events.Type = "push.close"
+)
+
+type _endpoint struct {
+	urlGen adtype.URLGenerator
+	conf   Config
+}
+
+// New creates new push notification endpoint
+func New(urlGen adtype.URLGenerator, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Codename of the endpoint
+func (e *_endpoint) Codename() string {
+	return "push"
+}
+
+// Handle request of the push notifications and return the payloads
+func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	resp := Response{Notifications: []*notification{}}
+	if response.Error() == nil {
+		for _, it := range templates.ResponseItems(response) {
+			if nt := e.prepareNotification(it, response); nt != nil {
+				resp.Notifications = append(resp.Notifications, nt)
+			}
+		}
+	}
+	ctx := request.HTTPRequest()
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetContentType("application/json")
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(ctx).Encode(resp); err != nil {
+		ctxlogger.Get(request.Context()).Error("push render json", zap.Error(err))
+	}
+	return response
+}
+
+// prepareNotification returns the payload of the item or nil if the item has no title or click URL
+func (e *_endpoint) prepareNotification(it adtype.ResponseItem, response adtype.Response) *notification {
+	title := it.ContentItemString(e.conf.titleField())
+	url, _ := e.urlGen.ClickURL(it, response)
+	if title == "" || url == "" {
+		return nil
+	}
+	nt := &notification{
+		ID:                 it.ID(),
+		Title:              title,
+		Body:               it.ContentItemString(e.conf.bodyField()),
+		Icon:               e.assetURL(it, e.conf.iconAsset()),
+		Badge:              e.assetURL(it, e.conf.badgeAsset()),
+		URL:                url,
+		TTL:                int64(e.conf.TTL.Seconds()),
+		RequireInteraction: e.conf.RequireInteraction,
+		Trackers: trackers{
+			Delivery: []string{endpointutil.PixelURL(e.urlGen, Delivery, it, response)},
+			Show: append([]string{
+				endpointutil.PixelURL(e.urlGen, events.Impression, it, response),
+				endpointutil.PixelURL(e.urlGen, events.View, it, response),
+			}, append(it.ImpressionTrackerLinks(), it.ViewTrackerLinks()...)...),
+			Click: it.ClickTrackerLinks(),
+			Close: []string{endpointutil.PixelURL(e.urlGen, Close, it, response)},
+		},
+	}
+	if asset := it.MainAsset(); asset != nil && asset.IsImage() {
+		nt.Image = e.urlGen.CDNURL(asset.URL)
+	}
+	for _, name := range e.conf.actionFields() {
+		if label := it.ContentItemString(name); label != "" {
+			nt.Actions = append(nt.Actions, &action{Action: name, Title: label, URL: url})
+		}
+	}
+	return nt
+}
+
+// assetURL returns the CDN URL of the named image asset or empty string
+func (e *_endpoint) assetURL(it adtype.ResponseItem, name string) string {
+	if asset := it.Assets().Asset(name); asset != nil && asset.IsImage() {
+		return e.urlGen.CDNURL(asset.URL)
+	}
+	return ""
+}
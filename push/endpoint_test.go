@@ -0,0 +1,98 @@
+package push
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+)
+
+func TestHandle(t *testing.T) {
+	var (
+		icon = &admodels.AdFileAsset{Name: "icon", URL: "icon.png", Type: types.AdFileAssetImageType}
+		ad1  = endpointtest.NewItem("ad1", types.FormatNativeType,
+			map[string]any{"title": "Title 1", "description": "Body 1", "cta": "Buy"}, icon)
+		ad2 = endpointtest.NewItem("ad2", types.FormatNativeType, map[string]any{"title": "Title 2"})
+		ad3 = endpointtest.NewItem("ad3", types.FormatNativeType, map[string]any{"description": "No title"})
+
+		request = endpointtest.NewRequest("https://ads.example.com/push?zone=1&count=3")
+		source  = &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+			ad1, &bidresponse.ResponseItemBlock{Items: []adtype.ResponseItem{ad2, ad3}},
+		}}
+	)
+	New(endpointtest.URLGen{}).Handle(source, request)
+
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != 200 {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/json" {
+		t.Errorf("content type = %q, want application/json", ct)
+	}
+	var resp Response
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(resp.Notifications) != 2 {
+		t.Fatalf("notifications = %d, want 2 of the single and multiple items", len(resp.Notifications))
+	}
+	for i, id := range []string{"ad1", "ad2"} {
+		nt := resp.Notifications[i]
+		if nt.ID != id || nt.URL != endpointtest.ClickURL(id) {
+			t.Errorf("notification %d = %v %q, want %s with the click URL", i, nt.ID, nt.URL, id)
+		}
+		tr := nt.Trackers
+		if !slices.Equal(tr.Delivery, []string{endpointtest.PixelURL(Delivery, id)}) {
+			t.Errorf("delivery trackers of %s = %v", id, tr.Delivery)
+		}
+		for _, want := range []string{
+			endpointtest.PixelURL(events.Impression, id),
+			endpointtest.PixelURL(events.View, id),
+			"https://imp.example.com/" + id,
+			"https://view.example.com/" + id,
+		} {
+			if !slices.Contains(tr.Show, want) {
+				t.Errorf("show trackers of %s must contain %q: %v", id, want, tr.Show)
+			}
+		}
+		if !slices.Equal(tr.Click, []string{"https://clk.example.com/" + id}) {
+			t.Errorf("click trackers of %s = %v", id, tr.Click)
+		}
+		if !slices.Equal(tr.Close, []string{endpointtest.PixelURL(Close, id)}) {
+			t.Errorf("close trackers of %s = %v", id, tr.Close)
+		}
+	}
+	if nt := resp.Notifications[0]; nt.Icon != endpointtest.CDNHost+"/icon.png" || len(nt.Actions) != 1 {
+		t.Errorf("notification ad1 icon = %q, actions = %d", nt.Icon, len(nt.Actions))
+	}
+}
+
+func TestHandleRobot(t *testing.T) {
+	var (
+		request = endpointtest.NewRequest("https://ads.example.com/push?zone=1")
+		source  = &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+			endpointtest.NewItem("ad1", types.FormatNativeType, map[string]any{"title": "Title 1"}),
+		}}
+	)
+	request.StateFlags |= bidrequest.BidRequestFlagBot
+	New(endpointtest.URLGen{}).Handle(source, request)
+
+	ctx := request.HTTPRequest()
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/json" {
+		t.Errorf("content type = %q, want application/json", ct)
+	}
+	if body := string(ctx.Response.Body()); body != "{\"notifications\":[]}\n" {
+		t.Errorf("robot response = %s, want empty notifications", body)
+	}
+	if source.Bids != 0 {
+		t.Errorf("robot must not run the auction, got %d bids", source.Bids)
+	}
+}
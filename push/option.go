@@ -0,0 +1,11 @@
+package push
+
+// Option of the push endpoint
+type Option func(e *_endpoint)
+
+// WithConfig sets the payload config
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
@@ -0,0 +1,38 @@
+package push
+
+//easyjson:json
+type action struct {
+	Action string `json:"action"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+//easyjson:json
+type trackers struct {
+	Delivery []string `json:"delivery,omitempty"`
+	Show     []string `json:"show,omitempty"`
+	Click    []string `json:"click,omitempty"`
+	Close    []string `json:"close,omitempty"`
+}
+
+//easyjson:json
+type notification struct {
+	ID                 any       `json:"id"`
+	Title              string    `json:"title"`
+	Body               string    `json:"body,omitempty"`
+	Icon               string    `json:"icon,omitempty"`
+	Image              string    `json:"image,omitempty"`
+	Badge              string    `json:"badge,omitempty"`
+	URL                string    `json:"url"`
+	Actions            []*action `json:"actions,omitempty"`
+	TTL                int64     `json:"ttl,omitempty"`
+	RequireInteraction bool      `json:"require_interaction,omitempty"`
+	Trackers           trackers  `json:"trackers"`
+}
+
+// Response with the push payloads of the winning items
+//
+//easyjson:json
+type Response struct {
+	Notifications []*notification `json:"notifications"`
+}
@@ -0,0 +1,162 @@
+// Code generated by qtc from "ad_amp.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line private/templates/ad_amp.qtpl:2
+package templates
+
+//line private/templates/ad_amp.qtpl:2
+import (
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Render the AMPHTML ad document of the item for `amp-ad` and `amp-embed`
+
+//line private/templates/ad_amp.qtpl:10
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line private/templates/ad_amp.qtpl:10
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line private/templates/ad_amp.qtpl:10
+func (r *QTPLRenderer) StreamAdRenderAMPBanner(qw422016 *qt422016.Writer, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_amp.qtpl:12
+	urlStr := r.URLGen.MustClickURL(it, resp)
+	asset := it.MainAsset()
+	config := it.Format().GetConfig()
+
+//line private/templates/ad_amp.qtpl:15
+	qw422016.N().S(`<!doctype html><html ⚡4ads><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,minimum-scale=1"><style amp4ads-boilerplate>body{visibility:hidden}</style><script async src="https://cdn.ampproject.org/amp4ads-v0.js"></script><script async custom-element="amp-analytics" src="https://cdn.ampproject.org/v0/amp-analytics-0.1.js"></script>`)
+//line private/templates/ad_amp.qtpl:24
+	streamadRenderAMPCSS(qw422016)
+//line private/templates/ad_amp.qtpl:24
+	qw422016.N().S(`</head><body><div class="banner">`)
+//line private/templates/ad_amp.qtpl:28
+	if asset != nil {
+//line private/templates/ad_amp.qtpl:28
+		qw422016.N().S(`<a target="_blank" href="`)
+//line private/templates/ad_amp.qtpl:29
+		qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_amp.qtpl:29
+		qw422016.N().S(`" class="image"><amp-img src="`)
+//line private/templates/ad_amp.qtpl:30
+		qw422016.E().S(safeURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_amp.qtpl:30
+		qw422016.N().S(`" layout="fill" alt=""></amp-img></a>`)
+//line private/templates/ad_amp.qtpl:32
+	}
+//line private/templates/ad_amp.qtpl:32
+	qw422016.N().S(`<div class="label">`)
+//line private/templates/ad_amp.qtpl:34
+	for _, field := range config.Fields {
+//line private/templates/ad_amp.qtpl:35
+		if val := it.ContentItem(field.Name); val != nil {
+//line private/templates/ad_amp.qtpl:36
+			if vl, _ := field.Prepare(val); vl != nil {
+//line private/templates/ad_amp.qtpl:36
+				qw422016.N().S(`<a target="_blank" href="`)
+//line private/templates/ad_amp.qtpl:37
+				qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_amp.qtpl:37
+				qw422016.N().S(`" class="`)
+//line private/templates/ad_amp.qtpl:37
+				qw422016.E().S(field.Name)
+//line private/templates/ad_amp.qtpl:37
+				qw422016.N().S(`">`)
+//line private/templates/ad_amp.qtpl:38
+				qw422016.E().S(gocast.Str(vl))
+//line private/templates/ad_amp.qtpl:38
+				qw422016.N().S(`</a>`)
+//line private/templates/ad_amp.qtpl:40
+			}
+//line private/templates/ad_amp.qtpl:41
+		}
+//line private/templates/ad_amp.qtpl:42
+	}
+//line private/templates/ad_amp.qtpl:42
+	qw422016.N().S(`</div></div>`)
+//line private/templates/ad_amp.qtpl:45
+	for _, link := range r.ampImpressionLinks(it, resp) {
+//line private/templates/ad_amp.qtpl:45
+		qw422016.N().S(`<amp-pixel src="`)
+//line private/templates/ad_amp.qtpl:46
+		qw422016.E().S(safeURL(link))
+//line private/templates/ad_amp.qtpl:46
+		qw422016.N().S(`" layout="nodisplay"></amp-pixel>`)
+//line private/templates/ad_amp.qtpl:47
+	}
+//line private/templates/ad_amp.qtpl:47
+	qw422016.N().S(`<amp-analytics><script type="application/json">`)
+//line private/templates/ad_amp.qtpl:49
+	qw422016.N().S(ampAnalytics(r.ampViewLinks(it, resp), r.viewRule(it)))
+//line private/templates/ad_amp.qtpl:49
+	qw422016.N().S(`</script></amp-analytics></body></html>`)
+//line private/templates/ad_amp.qtpl:53
+}
+
+//line private/templates/ad_amp.qtpl:53
+func (r *QTPLRenderer) WriteAdRenderAMPBanner(qq422016 qtio422016.Writer, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_amp.qtpl:53
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_amp.qtpl:53
+	r.StreamAdRenderAMPBanner(qw422016, resp, it)
+//line private/templates/ad_amp.qtpl:53
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_amp.qtpl:53
+}
+
+//line private/templates/ad_amp.qtpl:53
+func (r *QTPLRenderer) AdRenderAMPBanner(resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_amp.qtpl:53
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_amp.qtpl:53
+	r.WriteAdRenderAMPBanner(qb422016, resp, it)
+//line private/templates/ad_amp.qtpl:53
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_amp.qtpl:53
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_amp.qtpl:53
+	return qs422016
+//line private/templates/ad_amp.qtpl:53
+}
+
+//line private/templates/ad_amp.qtpl:56
+func streamadRenderAMPCSS(qw422016 *qt422016.Writer) {
+//line private/templates/ad_amp.qtpl:56
+	qw422016.N().S(`<style amp-custom>html, body {padding: 0;margin: 0;height: 100%;background: #fff;font-family: Arial,Helvetica,sans-serif;}.banner {display: flex;height: 100%;overflow: hidden;}.banner .image {position: relative;display: block;width: 40%;height: 100%;background-color: #eee;}.banner .image amp-img img {object-fit: cover;}.banner .label {flex: 1;padding: 2px 5px;overflow: hidden;}.banner .label a {display: block;text-decoration: none;word-wrap: break-word;overflow: hidden;}.banner .label .title, .banner .label .description {font-size: 14px;line-height: 1.3em;max-height: 65px;color: #000;}.banner .label .brand, .banner .label .brandname, .banner .label .phone {font-size: 11px;font-weight: 700;line-height: 1em;max-height: 22px;color: #999;padding: 3px 0 0;}</style>`)
+//line private/templates/ad_amp.qtpl:106
+}
+
+//line private/templates/ad_amp.qtpl:106
+func writeadRenderAMPCSS(qq422016 qtio422016.Writer) {
+//line private/templates/ad_amp.qtpl:106
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_amp.qtpl:106
+	streamadRenderAMPCSS(qw422016)
+//line private/templates/ad_amp.qtpl:106
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_amp.qtpl:106
+}
+
+//line private/templates/ad_amp.qtpl:106
+func adRenderAMPCSS() string {
+//line private/templates/ad_amp.qtpl:106
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_amp.qtpl:106
+	writeadRenderAMPCSS(qb422016)
+//line private/templates/ad_amp.qtpl:106
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_amp.qtpl:106
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_amp.qtpl:106
+	return qs422016
+//line private/templates/ad_amp.qtpl:106
+}
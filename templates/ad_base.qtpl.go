@@ -11,9 +11,10 @@ package templates
 import (
 	"github.com/geniusrabbit/adcorelib/adtype"
 	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+)
 
 //line private/templates/ad_base.qtpl:10
-
+import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
@@ -25,242 +26,302 @@ var (
 	_ = qt422016.AcquireByteBuffer
 )
 
+//line private/templates/ad_base.qtpl:10
+func streamadActionScript(qw422016 *qt422016.Writer, p *Params) {
+//line private/templates/ad_base.qtpl:10
+	qw422016.N().S(`<script type="text/javascript"`)
 //line private/templates/ad_base.qtpl:11
-var Debug bool
-var URLGen adtype.URLGenerator
-
-//line private/templates/ad_base.qtpl:15
-func streamadActionScript(qw422016 *qt422016.Writer) {
-//line private/templates/ad_base.qtpl:15
-	qw422016.N().S(`<script type="text/javascript">var t = new Date();function e(u, st) {var delta = new Date() - t;var qPixel = new Image();qPixel.src = u+'&r='+st+'&d='+delta;};</script>`)
-//line private/templates/ad_base.qtpl:24
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_base.qtpl:11
+	qw422016.N().S(`>var t = new Date();function e(u, st) {var delta = new Date() - t;var qPixel = new Image();qPixel.src = u+'&r='+st+'&d='+delta;};`)
+//line private/templates/ad_base.qtpl:18
+	if p.safeFrame() {
+//line private/templates/ad_base.qtpl:18
+		qw422016.N().S(`var adSafeFrame = (function() {var ext = window.$sf && window.$sf.ext;var handlers = [];if (!ext) { return null; }try {ext.register(document.documentElement.clientWidth,document.documentElement.clientHeight,function(status, data) {for (var i = 0; i < handlers.length; i++) { handlers[i](status, data); }});} catch (err) { return null; }return {geom: function() { return ext.geom(); },inView: function() { return ext.inViewPercentage() / 100; },expand: function(dx, dy, push) { ext.expand({r: dx, b: dy, push: !!push}); },collapse: function() { ext.collapse(); },on: function(fn) { handlers.push(fn); }};})();`)
+//line private/templates/ad_base.qtpl:39
+	}
+//line private/templates/ad_base.qtpl:40
+	if p.mraid() {
+//line private/templates/ad_base.qtpl:40
+		qw422016.N().S(`var adMRAID = (function() {var m = window.mraid;if (!m) { return null; }document.addEventListener('click', function(ev) {var a = ev.target && ev.target.closest ? ev.target.closest('a[href]') : null;if (a) { ev.preventDefault(); m.open(a.href); }}, true);return {ready: function(fn) {if (m.getState() !== 'loading') { fn(); } else { m.addEventListener('ready', function() { fn(); }); }},exposure: function(fn) {if (parseFloat(m.getVersion()) >= 3) {m.addEventListener('exposureChange', function(pct) { fn(pct / 100); });} else {m.addEventListener('viewableChange', function(v) { fn(v ? 1 : 0); });fn(m.isViewable() ? 1 : 0);}},open: function(url) { m.open(url); },expand: function(url) { if (url) { m.expand(url); } else { m.expand(); } },resize: function(w, h, x, y) {m.setResizeProperties({width: w, height: h, offsetX: x || 0, offsetY: y || 0, allowOffscreen: false});m.resize();},close: function() { m.close(); },on: function(event, fn) { m.addEventListener(event, fn); }};})();`)
+//line private/templates/ad_base.qtpl:70
+	}
+//line private/templates/ad_base.qtpl:70
+	qw422016.N().S(`function rd(fn) {if (window.adMRAID) { adMRAID.ready(fn); } else { fn(); }};function vw(el, ratio, ms, cb) {if (!el) { cb(0); return; }var tm = null;var seen = function(visible, st) {if (visible && !tm) {tm = setTimeout(function() { tm = -1; cb(st); }, ms);} else if (!visible && tm && tm !== -1) {clearTimeout(tm);tm = null;}};if (window.adMRAID) {adMRAID.exposure(function(r) { seen(r >= ratio && !document.hidden, 4); });return;}if (window.adSafeFrame) {var check = function() { seen(adSafeFrame.inView() >= ratio && !document.hidden, 3); };adSafeFrame.on(function(status) { if (status === 'geom-update') { check(); } });check();return;}if ('IntersectionObserver' in window) {var io = new IntersectionObserver(function(entries) {for (var i = 0; i < entries.length; i++) {seen(entries[i].isIntersecting && entries[i].intersectionRatio >= ratio && !document.hidden, 1);}if (tm === -1) { io.disconnect(); }}, {threshold: [0, ratio]});io.observe(el);return;}var iv = setInterval(function() {if (tm === -1) { clearInterval(iv); return; }var b = el.getBoundingClientRect();var ww = window.innerWidth || document.documentElement.clientWidth;var wh = window.innerHeight || document.documentElement.clientHeight;var x = Math.max(0, Math.min(b.right, ww) - Math.max(b.left, 0));var y = Math.max(0, Math.min(b.bottom, wh) - Math.max(b.top, 0));var area = b.width * b.height;seen(area > 0 && (x * y) / area >= ratio && !document.hidden, 2);}, 100);};</script>`)
+//line private/templates/ad_base.qtpl:117
 }
 
-//line private/templates/ad_base.qtpl:24
-func writeadActionScript(qq422016 qtio422016.Writer) {
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
+func writeadActionScript(qq422016 qtio422016.Writer, p *Params) {
+//line private/templates/ad_base.qtpl:117
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_base.qtpl:24
-	streamadActionScript(qw422016)
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
+	streamadActionScript(qw422016, p)
+//line private/templates/ad_base.qtpl:117
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
 }
 
-//line private/templates/ad_base.qtpl:24
-func adActionScript() string {
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
+func adActionScript(p *Params) string {
+//line private/templates/ad_base.qtpl:117
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_base.qtpl:24
-	writeadActionScript(qb422016)
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
+	writeadActionScript(qb422016, p)
+//line private/templates/ad_base.qtpl:117
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
 	return qs422016
-//line private/templates/ad_base.qtpl:24
+//line private/templates/ad_base.qtpl:117
 }
 
-//line private/templates/ad_base.qtpl:26
-func streamadHeader(qw422016 *qt422016.Writer) {
-//line private/templates/ad_base.qtpl:26
-	qw422016.N().S(`<!DOCTYPE html><html><head><meta name="viewport" content="width=device-width, initial-scale=1"><meta charset="utf-8" /><style type="text/css">*, body, html { margin: 0; padding: 0; border:none; }body, html { width: 100%; height: 100%; background: transparent }iframe[seamless] {background-color: transparent;border: 0px none transparent;padding: 0px;overflow: hidden;margin: 0;}</style></head><body>`)
-//line private/templates/ad_base.qtpl:42
-	streamadActionScript(qw422016)
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:119
+func streamadHeader(qw422016 *qt422016.Writer, p *Params) {
+//line private/templates/ad_base.qtpl:119
+	qw422016.N().S(`<!DOCTYPE html><html><head><meta name="viewport" content="width=device-width, initial-scale=1"><meta charset="utf-8" />`)
+//line private/templates/ad_base.qtpl:123
+	if p.mraid() {
+//line private/templates/ad_base.qtpl:123
+		qw422016.N().S(`<script type="text/javascript"`)
+//line private/templates/ad_base.qtpl:124
+		qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_base.qtpl:124
+		qw422016.N().S(`src="mraid.js"></script>`)
+//line private/templates/ad_base.qtpl:125
+	}
+//line private/templates/ad_base.qtpl:125
+	qw422016.N().S(`<style type="text/css"`)
+//line private/templates/ad_base.qtpl:126
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_base.qtpl:126
+	qw422016.N().S(`>*, body, html { margin: 0; padding: 0; border:none; }body, html { width: 100%; height: 100%; background: transparent }iframe[seamless] {background-color: transparent;border: 0px none transparent;padding: 0px;overflow: hidden;margin: 0;}</style></head><body>`)
+//line private/templates/ad_base.qtpl:138
+	streamadActionScript(qw422016, p)
+//line private/templates/ad_base.qtpl:139
 }
 
-//line private/templates/ad_base.qtpl:43
-func writeadHeader(qq422016 qtio422016.Writer) {
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
+func writeadHeader(qq422016 qtio422016.Writer, p *Params) {
+//line private/templates/ad_base.qtpl:139
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_base.qtpl:43
-	streamadHeader(qw422016)
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
+	streamadHeader(qw422016, p)
+//line private/templates/ad_base.qtpl:139
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
 }
 
-//line private/templates/ad_base.qtpl:43
-func adHeader() string {
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
+func adHeader(p *Params) string {
+//line private/templates/ad_base.qtpl:139
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_base.qtpl:43
-	writeadHeader(qb422016)
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
+	writeadHeader(qb422016, p)
+//line private/templates/ad_base.qtpl:139
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
 	return qs422016
-//line private/templates/ad_base.qtpl:43
+//line private/templates/ad_base.qtpl:139
 }
 
-//line private/templates/ad_base.qtpl:46
+//line private/templates/ad_base.qtpl:142
 func streamadFooter(qw422016 *qt422016.Writer) {
-//line private/templates/ad_base.qtpl:46
+//line private/templates/ad_base.qtpl:142
 	qw422016.N().S(`</body></html>`)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 }
 
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 func writeadFooter(qq422016 qtio422016.Writer) {
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	streamadFooter(qw422016)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 }
 
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 func adFooter() string {
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	writeadFooter(qb422016)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 	return qs422016
-//line private/templates/ad_base.qtpl:48
+//line private/templates/ad_base.qtpl:144
 }
 
 // Generate pixel base code
 
-//line private/templates/ad_base.qtpl:52
-func streamadPixel(qw422016 *qt422016.Writer, adID, spotID, campID int, tag string) {
-//line private/templates/ad_base.qtpl:52
-	qw422016.N().S(`<script type="text/javascript">function u`)
-//line private/templates/ad_base.qtpl:54
+//line private/templates/ad_base.qtpl:148
+func streamadPixel(qw422016 *qt422016.Writer, p *Params, adID, spotID, campID int, tag string) {
+//line private/templates/ad_base.qtpl:148
+	qw422016.N().S(`<script type="text/javascript"`)
+//line private/templates/ad_base.qtpl:149
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_base.qtpl:149
+	qw422016.N().S(`>function u`)
+//line private/templates/ad_base.qtpl:150
 	qw422016.N().D(adID)
-//line private/templates/ad_base.qtpl:54
+//line private/templates/ad_base.qtpl:150
 	qw422016.N().S(`(st){}</script>`)
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
 }
 
-//line private/templates/ad_base.qtpl:56
-func writeadPixel(qq422016 qtio422016.Writer, adID, spotID, campID int, tag string) {
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
+func writeadPixel(qq422016 qtio422016.Writer, p *Params, adID, spotID, campID int, tag string) {
+//line private/templates/ad_base.qtpl:152
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_base.qtpl:56
-	streamadPixel(qw422016, adID, spotID, campID, tag)
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
+	streamadPixel(qw422016, p, adID, spotID, campID, tag)
+//line private/templates/ad_base.qtpl:152
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
 }
 
-//line private/templates/ad_base.qtpl:56
-func adPixel(adID, spotID, campID int, tag string) string {
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
+func adPixel(p *Params, adID, spotID, campID int, tag string) string {
+//line private/templates/ad_base.qtpl:152
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_base.qtpl:56
-	writeadPixel(qb422016, adID, spotID, campID, tag)
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
+	writeadPixel(qb422016, p, adID, spotID, campID, tag)
+//line private/templates/ad_base.qtpl:152
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
 	return qs422016
-//line private/templates/ad_base.qtpl:56
+//line private/templates/ad_base.qtpl:152
 }
 
 // Generate pixel base code for adresult item
 
-//line private/templates/ad_base.qtpl:60
-func streamadPixelItem(qw422016 *qt422016.Writer, ad adtype.ResponseItem, resp adtype.Response) {
-//line private/templates/ad_base.qtpl:61
+//line private/templates/ad_base.qtpl:156
+func (r *QTPLRenderer) streamadPixelItem(qw422016 *qt422016.Writer, p *Params, ad adtype.ResponseItem, resp adtype.Response) {
+//line private/templates/ad_base.qtpl:157
 	if ad != nil && resp != nil {
-//line private/templates/ad_base.qtpl:61
-		qw422016.N().S(`<script type="text/javascript">`)
-//line private/templates/ad_base.qtpl:63
-		var u, _ = URLGen.PixelURL(events.Impression, events.StatusSuccess, ad, resp, false)
+//line private/templates/ad_base.qtpl:157
+		qw422016.N().S(`<script type="text/javascript"`)
+//line private/templates/ad_base.qtpl:158
+		qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_base.qtpl:158
+		qw422016.N().S(`>`)
+//line private/templates/ad_base.qtpl:159
+		if p.pixels() {
+//line private/templates/ad_base.qtpl:160
+			var u, _ = r.URLGen.PixelURL(events.Impression, events.StatusSuccess, ad, resp, false)
 
-//line private/templates/ad_base.qtpl:64
-		var v, _ = URLGen.PixelURL(events.View, events.StatusSuccess, ad, resp, false)
+//line private/templates/ad_base.qtpl:161
+			var v, _ = r.URLGen.PixelURL(events.View, events.StatusSuccess, ad, resp, false)
 
-//line private/templates/ad_base.qtpl:64
-		qw422016.N().S(`function u`)
-//line private/templates/ad_base.qtpl:65
-		qw422016.N().S(ad.AdID())
-//line private/templates/ad_base.qtpl:65
-		qw422016.N().S(`(st){e('`)
-//line private/templates/ad_base.qtpl:65
-		qw422016.N().S(u)
-//line private/templates/ad_base.qtpl:65
-		qw422016.N().S(`',st)}function v`)
-//line private/templates/ad_base.qtpl:66
-		qw422016.N().S(ad.AdID())
-//line private/templates/ad_base.qtpl:66
-		qw422016.N().S(`(st){e('`)
-//line private/templates/ad_base.qtpl:66
-		qw422016.N().S(v)
-//line private/templates/ad_base.qtpl:66
-		qw422016.N().S(`',st)}</script>`)
-//line private/templates/ad_base.qtpl:68
+//line private/templates/ad_base.qtpl:161
+			qw422016.N().S(`function u`)
+//line private/templates/ad_base.qtpl:162
+			qw422016.N().S(jsIdent(ad.AdID()))
+//line private/templates/ad_base.qtpl:162
+			qw422016.N().S(`(st){e('`)
+//line private/templates/ad_base.qtpl:162
+			qw422016.N().J(u)
+//line private/templates/ad_base.qtpl:162
+			qw422016.N().S(`',st)}function v`)
+//line private/templates/ad_base.qtpl:163
+			qw422016.N().S(jsIdent(ad.AdID()))
+//line private/templates/ad_base.qtpl:163
+			qw422016.N().S(`(st){e('`)
+//line private/templates/ad_base.qtpl:163
+			qw422016.N().J(v)
+//line private/templates/ad_base.qtpl:163
+			qw422016.N().S(`',st)}`)
+//line private/templates/ad_base.qtpl:164
+		} else {
+//line private/templates/ad_base.qtpl:164
+			qw422016.N().S(`function u`)
+//line private/templates/ad_base.qtpl:165
+			qw422016.N().S(jsIdent(ad.AdID()))
+//line private/templates/ad_base.qtpl:165
+			qw422016.N().S(`(st){}function v`)
+//line private/templates/ad_base.qtpl:166
+			qw422016.N().S(jsIdent(ad.AdID()))
+//line private/templates/ad_base.qtpl:166
+			qw422016.N().S(`(st){}`)
+//line private/templates/ad_base.qtpl:167
+		}
+//line private/templates/ad_base.qtpl:167
+		qw422016.N().S(`</script>`)
+//line private/templates/ad_base.qtpl:169
 	}
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
 }
 
-//line private/templates/ad_base.qtpl:69
-func writeadPixelItem(qq422016 qtio422016.Writer, ad adtype.ResponseItem, resp adtype.Response) {
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
+func (r *QTPLRenderer) writeadPixelItem(qq422016 qtio422016.Writer, p *Params, ad adtype.ResponseItem, resp adtype.Response) {
+//line private/templates/ad_base.qtpl:170
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_base.qtpl:69
-	streamadPixelItem(qw422016, ad, resp)
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
+	r.streamadPixelItem(qw422016, p, ad, resp)
+//line private/templates/ad_base.qtpl:170
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
 }
 
-//line private/templates/ad_base.qtpl:69
-func adPixelItem(ad adtype.ResponseItem, resp adtype.Response) string {
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
+func (r *QTPLRenderer) adPixelItem(p *Params, ad adtype.ResponseItem, resp adtype.Response) string {
+//line private/templates/ad_base.qtpl:170
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_base.qtpl:69
-	writeadPixelItem(qb422016, ad, resp)
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
+	r.writeadPixelItem(qb422016, p, ad, resp)
+//line private/templates/ad_base.qtpl:170
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
 	return qs422016
-//line private/templates/ad_base.qtpl:69
+//line private/templates/ad_base.qtpl:170
 }
 
-//line private/templates/ad_base.qtpl:72
-func streampreloader(qw422016 *qt422016.Writer) {
-//line private/templates/ad_base.qtpl:72
-	qw422016.N().S(`<style>.loading {position: absolute;height: 100%;width: 100%;top: 0;left: 0;background: #fefefe;display: block;z-index: 1000;}.loading .progress {position: fixed;display: block;width: 100%;height: 1.5pt;background: deepskyblue;}.loading .progress:before {content: "";position: absolute;left: 0;top: 0;width: 100%;height: 100%;transform: translateX(-100%);background: #ccc;animation: progress 3s ease infinite;}.loading .badge {position: absolute;left: 50%;top: 50%;display: block;padding: 3pt;margin: -15pt 0 0 -15pt;border: 1.5pt solid #ddd;border-radius: 5pt;font-family: Helvetica,sans-serif;font-size: 12pt;color: #ddd;}@-webkit-keyframes progress {50% {-webkit-transform: translateX(0%);transform: translateX(0%);}100% {-webkit-transform: translateX(100%);transform: translateX(100%);}}@keyframes progress {50% {-webkit-transform: translateX(0%);transform: translateX(0%);}100% {-webkit-transform: translateX(100%);transform: translateX(100%);}}}</style><div id="loadingBlock" class="loading"><div class="progress"></div><div class="badge">ADS</div></div>`)
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:173
+func streampreloader(qw422016 *qt422016.Writer, p *Params) {
+//line private/templates/ad_base.qtpl:173
+	qw422016.N().S(`<style type="text/css"`)
+//line private/templates/ad_base.qtpl:174
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_base.qtpl:174
+	qw422016.N().S(`>.loading {position: absolute;height: 100%;width: 100%;top: 0;left: 0;background: #fefefe;display: block;z-index: 1000;}.loading .progress {position: fixed;display: block;width: 100%;height: 1.5pt;background: deepskyblue;}.loading .progress:before {content: "";position: absolute;left: 0;top: 0;width: 100%;height: 100%;transform: translateX(-100%);background: #ccc;animation: progress 3s ease infinite;}.loading .badge {position: absolute;left: 50%;top: 50%;display: block;padding: 3pt;margin: -15pt 0 0 -15pt;border: 1.5pt solid #ddd;border-radius: 5pt;font-family: Helvetica,sans-serif;font-size: 12pt;color: #ddd;}@-webkit-keyframes progress {50% {-webkit-transform: translateX(0%);transform: translateX(0%);}100% {-webkit-transform: translateX(100%);transform: translateX(100%);}}@keyframes progress {50% {-webkit-transform: translateX(0%);transform: translateX(0%);}100% {-webkit-transform: translateX(100%);transform: translateX(100%);}}}</style><div id="loadingBlock" class="loading"><div class="progress"></div><div class="badge">ADS</div></div>`)
+//line private/templates/ad_base.qtpl:243
 }
 
-//line private/templates/ad_base.qtpl:142
-func writepreloader(qq422016 qtio422016.Writer) {
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
+func writepreloader(qq422016 qtio422016.Writer, p *Params) {
+//line private/templates/ad_base.qtpl:243
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_base.qtpl:142
-	streampreloader(qw422016)
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
+	streampreloader(qw422016, p)
+//line private/templates/ad_base.qtpl:243
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
 }
 
-//line private/templates/ad_base.qtpl:142
-func preloader() string {
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
+func preloader(p *Params) string {
+//line private/templates/ad_base.qtpl:243
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_base.qtpl:142
-	writepreloader(qb422016)
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
+	writepreloader(qb422016, p)
+//line private/templates/ad_base.qtpl:243
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
 	return qs422016
-//line private/templates/ad_base.qtpl:142
+//line private/templates/ad_base.qtpl:243
 }
@@ -6,11 +6,11 @@ package templates
 
 //line private/templates/ad_dinamic_proxy.qtpl:2
 import (
-	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
 	"github.com/geniusrabbit/adcorelib/adtype"
+)
 
 //line private/templates/ad_dinamic_proxy.qtpl:7
-
+import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
@@ -23,73 +23,131 @@ var (
 )
 
 //line private/templates/ad_dinamic_proxy.qtpl:7
-func StreamAdRenderDinamicProxyBanner(qw422016 *qt422016.Writer, request adtype.BidRequester) {
-//line private/templates/ad_dinamic_proxy.qtpl:8
-	streamadHeader(qw422016)
+func (r *QTPLRenderer) StreamAdRenderDinamicProxyBanner(qw422016 *qt422016.Writer, p *Params, request adtype.BidRequester) {
 //line private/templates/ad_dinamic_proxy.qtpl:9
-	streampreloader(qw422016)
-//line private/templates/ad_dinamic_proxy.qtpl:10
-	streamadRenderNativeCSS(qw422016)
-//line private/templates/ad_dinamic_proxy.qtpl:11
-	var script = URLGen.LibURL("/embedded.js")
+	loader := &r.Loader
+	script := r.URLGen.LibURL(loader.script())
+	passback := loader.passback(request)
 
-//line private/templates/ad_dinamic_proxy.qtpl:11
-	qw422016.N().S(`<ins id="element_`)
-//line private/templates/ad_dinamic_proxy.qtpl:12
-	qw422016.N().D(int(request.TargetID()))
-//line private/templates/ad_dinamic_proxy.qtpl:12
-	qw422016.N().S(`"></ins><script type="text/javascript" src="`)
-//line private/templates/ad_dinamic_proxy.qtpl:13
-	qw422016.N().S(script)
 //line private/templates/ad_dinamic_proxy.qtpl:13
-	qw422016.N().S(`"></script><script type="text/javascript">!(function(){(new EmbeddedAd({`)
-//line private/templates/ad_dinamic_proxy.qtpl:16
-	if Debug {
+	streamadHeader(qw422016, p)
+//line private/templates/ad_dinamic_proxy.qtpl:14
+	if loader.Preloader != "" {
+//line private/templates/ad_dinamic_proxy.qtpl:15
+		qw422016.N().S(p.nonceHTML(loader.Preloader))
 //line private/templates/ad_dinamic_proxy.qtpl:16
-		qw422016.N().S(`JSONPLink: '//`)
-//line private/templates/ad_dinamic_proxy.qtpl:17
-		qw422016.N().S(request.ServiceDomain())
+	} else if !loader.DisablePreloader {
 //line private/templates/ad_dinamic_proxy.qtpl:17
+		streampreloader(qw422016, p)
+//line private/templates/ad_dinamic_proxy.qtpl:18
+	}
+//line private/templates/ad_dinamic_proxy.qtpl:19
+	streamadRenderNativeCSS(qw422016, p, r.Themes.Theme(request))
+//line private/templates/ad_dinamic_proxy.qtpl:19
+	qw422016.N().S(`<ins id="element_`)
+//line private/templates/ad_dinamic_proxy.qtpl:20
+	qw422016.N().D(int(request.TargetID()))
+//line private/templates/ad_dinamic_proxy.qtpl:20
+	qw422016.N().S(`"></ins>`)
+//line private/templates/ad_dinamic_proxy.qtpl:21
+	if passback != "" {
+//line private/templates/ad_dinamic_proxy.qtpl:21
+		qw422016.N().S(`<template id="passback_`)
+//line private/templates/ad_dinamic_proxy.qtpl:22
+		qw422016.N().D(int(request.TargetID()))
+//line private/templates/ad_dinamic_proxy.qtpl:22
+		qw422016.N().S(`">`)
+//line private/templates/ad_dinamic_proxy.qtpl:22
+		qw422016.N().S(p.nonceHTML(passback))
+//line private/templates/ad_dinamic_proxy.qtpl:22
+		qw422016.N().S(`</template>`)
+//line private/templates/ad_dinamic_proxy.qtpl:23
+	}
+//line private/templates/ad_dinamic_proxy.qtpl:23
+	qw422016.N().S(`<script type="text/javascript"`)
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	qw422016.N().S(`src="`)
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	qw422016.E().S(safeURL(script))
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	qw422016.N().S(`"`)
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	if loader.Integrity != "" {
+//line private/templates/ad_dinamic_proxy.qtpl:24
+		qw422016.N().S(`integrity="`)
+//line private/templates/ad_dinamic_proxy.qtpl:24
+		qw422016.E().S(loader.Integrity)
+//line private/templates/ad_dinamic_proxy.qtpl:24
+		qw422016.N().S(`" crossorigin="anonymous"`)
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	}
+//line private/templates/ad_dinamic_proxy.qtpl:24
+	qw422016.N().S(`></script><script type="text/javascript"`)
+//line private/templates/ad_dinamic_proxy.qtpl:25
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_dinamic_proxy.qtpl:25
+	qw422016.N().S(`>!(function(){var removeLoader = function() {var loader = window.document.getElementById('loadingBlock');if (loader) {loader.parentElement.removeChild(loader);}};(new EmbeddedAd({`)
+//line private/templates/ad_dinamic_proxy.qtpl:33
+	if r.Debug {
+//line private/templates/ad_dinamic_proxy.qtpl:33
+		qw422016.N().S(`JSONPLink: '//`)
+//line private/templates/ad_dinamic_proxy.qtpl:34
+		qw422016.N().J(request.ServiceDomain())
+//line private/templates/ad_dinamic_proxy.qtpl:34
 		qw422016.N().S(`/b/dynamic/{<id>}?format=jsonp&',`)
-//line private/templates/ad_dinamic_proxy.qtpl:17
+//line private/templates/ad_dinamic_proxy.qtpl:34
 	}
-//line private/templates/ad_dinamic_proxy.qtpl:17
+//line private/templates/ad_dinamic_proxy.qtpl:34
 	qw422016.N().S(`element: "element_`)
-//line private/templates/ad_dinamic_proxy.qtpl:18
+//line private/templates/ad_dinamic_proxy.qtpl:35
 	qw422016.N().D(int(request.TargetID()))
-//line private/templates/ad_dinamic_proxy.qtpl:18
+//line private/templates/ad_dinamic_proxy.qtpl:35
 	qw422016.N().S(`",zone_id:`)
-//line private/templates/ad_dinamic_proxy.qtpl:19
+//line private/templates/ad_dinamic_proxy.qtpl:36
 	qw422016.N().D(int(request.TargetID()))
-//line private/templates/ad_dinamic_proxy.qtpl:19
-	qw422016.N().S(`})).on('render', function() {var loader = window.document.getElementById('loadingBlock');if (loader) {loader.parentElement.removeChild(loader);}}).on('error', function(err) {console.log(err);}).render();})();</script>`)
-//line private/templates/ad_dinamic_proxy.qtpl:30
+//line private/templates/ad_dinamic_proxy.qtpl:36
+	for _, param := range loader.params(request) {
+//line private/templates/ad_dinamic_proxy.qtpl:36
+		qw422016.N().S(`,`)
+//line private/templates/ad_dinamic_proxy.qtpl:36
+		qw422016.N().S(param)
+//line private/templates/ad_dinamic_proxy.qtpl:36
+	}
+//line private/templates/ad_dinamic_proxy.qtpl:36
+	qw422016.N().S(`})).on('render', removeLoader).on('error', function(err) {console.log(err);removeLoader();var passback = window.document.getElementById('passback_`)
+//line private/templates/ad_dinamic_proxy.qtpl:40
+	qw422016.N().D(int(request.TargetID()))
+//line private/templates/ad_dinamic_proxy.qtpl:40
+	qw422016.N().S(`');if (passback && passback.content) {passback.parentElement.insertBefore(passback.content.cloneNode(true), passback);}}).render();})();</script>`)
+//line private/templates/ad_dinamic_proxy.qtpl:47
 	streamadFooter(qw422016)
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
 }
 
-//line private/templates/ad_dinamic_proxy.qtpl:31
-func WriteAdRenderDinamicProxyBanner(qq422016 qtio422016.Writer, request adtype.BidRequester) {
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
+func (r *QTPLRenderer) WriteAdRenderDinamicProxyBanner(qq422016 qtio422016.Writer, p *Params, request adtype.BidRequester) {
+//line private/templates/ad_dinamic_proxy.qtpl:48
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_dinamic_proxy.qtpl:31
-	StreamAdRenderDinamicProxyBanner(qw422016, request)
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
+	r.StreamAdRenderDinamicProxyBanner(qw422016, p, request)
+//line private/templates/ad_dinamic_proxy.qtpl:48
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
 }
 
-//line private/templates/ad_dinamic_proxy.qtpl:31
-func AdRenderDinamicProxyBanner(request *bidrequest.BidRequest) string {
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
+func (r *QTPLRenderer) AdRenderDinamicProxyBanner(p *Params, request adtype.BidRequester) string {
+//line private/templates/ad_dinamic_proxy.qtpl:48
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_dinamic_proxy.qtpl:31
-	WriteAdRenderDinamicProxyBanner(qb422016, request)
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
+	r.WriteAdRenderDinamicProxyBanner(qb422016, p, request)
+//line private/templates/ad_dinamic_proxy.qtpl:48
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
 	return qs422016
-//line private/templates/ad_dinamic_proxy.qtpl:31
+//line private/templates/ad_dinamic_proxy.qtpl:48
 }
@@ -0,0 +1,371 @@
+// Code generated by qtc from "ad_layouts.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line private/templates/ad_layouts.qtpl:2
+package templates
+
+//line private/templates/ad_layouts.qtpl:2
+import (
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Render the image banner of standard IAB formats
+
+//line private/templates/ad_layouts.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line private/templates/ad_layouts.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line private/templates/ad_layouts.qtpl:8
+func (r *QTPLRenderer) streamadRenderImageBanner(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_layouts.qtpl:10
+	urlStr := r.URLGen.MustClickURL(it, resp)
+	asset := it.MainAsset()
+	adID := jsIdent(it.AdID())
+	view := r.viewRule(it)
+
+//line private/templates/ad_layouts.qtpl:15
+	streamadRenderLayoutCSS(qw422016, p)
+//line private/templates/ad_layouts.qtpl:15
+	qw422016.N().S(`<a target="_blank" href="`)
+//line private/templates/ad_layouts.qtpl:16
+	qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_layouts.qtpl:16
+	qw422016.N().S(`" id="ad_`)
+//line private/templates/ad_layouts.qtpl:16
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:16
+	qw422016.N().S(`" class="image-banner"><img id="img_`)
+//line private/templates/ad_layouts.qtpl:17
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:17
+	qw422016.N().S(`" src="`)
+//line private/templates/ad_layouts.qtpl:17
+	qw422016.E().S(safeURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_layouts.qtpl:17
+	qw422016.N().S(`" alt="" /></a><script type="text/javascript"`)
+//line private/templates/ad_layouts.qtpl:19
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_layouts.qtpl:19
+	qw422016.N().S(`>(function(img){var ld = function() {rd(function() {u`)
+//line private/templates/ad_layouts.qtpl:23
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:23
+	qw422016.N().S(`(1);vw(document.getElementById('ad_`)
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().S(`'),`)
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().S(`,`)
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().D(view.Duration())
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().S(`, v`)
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:24
+	qw422016.N().S(`);});};if (img.complete && img.naturalWidth) { ld(); return; }img.addEventListener('load', ld);img.addEventListener('error', function() { u`)
+//line private/templates/ad_layouts.qtpl:29
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:29
+	qw422016.N().S(`(0);v`)
+//line private/templates/ad_layouts.qtpl:29
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:29
+	qw422016.N().S(`(0); });})(document.getElementById('img_`)
+//line private/templates/ad_layouts.qtpl:30
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:30
+	qw422016.N().S(`'));</script>`)
+//line private/templates/ad_layouts.qtpl:32
+}
+
+//line private/templates/ad_layouts.qtpl:32
+func (r *QTPLRenderer) writeadRenderImageBanner(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_layouts.qtpl:32
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_layouts.qtpl:32
+	r.streamadRenderImageBanner(qw422016, p, resp, it)
+//line private/templates/ad_layouts.qtpl:32
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_layouts.qtpl:32
+}
+
+//line private/templates/ad_layouts.qtpl:32
+func (r *QTPLRenderer) adRenderImageBanner(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_layouts.qtpl:32
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_layouts.qtpl:32
+	r.writeadRenderImageBanner(qb422016, p, resp, it)
+//line private/templates/ad_layouts.qtpl:32
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_layouts.qtpl:32
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_layouts.qtpl:32
+	return qs422016
+//line private/templates/ad_layouts.qtpl:32
+}
+
+// Render the creative of `iframe_url` in the frame
+
+//line private/templates/ad_layouts.qtpl:36
+func (r *QTPLRenderer) streamadRenderIFrame(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_layouts.qtpl:38
+	adID := jsIdent(it.AdID())
+	view := r.viewRule(it)
+
+//line private/templates/ad_layouts.qtpl:41
+	streamadRenderLayoutCSS(qw422016, p)
+//line private/templates/ad_layouts.qtpl:41
+	qw422016.N().S(`<iframe id="ad_`)
+//line private/templates/ad_layouts.qtpl:42
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:42
+	qw422016.N().S(`" class="ad-frame" src="`)
+//line private/templates/ad_layouts.qtpl:42
+	qw422016.E().S(safeURL(it.ContentItemString(adtype.ContentItemIFrameURL)))
+//line private/templates/ad_layouts.qtpl:42
+	qw422016.N().S(`" seamless frameborder="0" scrolling="no"></iframe><script type="text/javascript"`)
+//line private/templates/ad_layouts.qtpl:43
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_layouts.qtpl:43
+	qw422016.N().S(`>document.getElementById('ad_`)
+//line private/templates/ad_layouts.qtpl:44
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:44
+	qw422016.N().S(`').addEventListener('load', function() {rd(function() {u`)
+//line private/templates/ad_layouts.qtpl:46
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:46
+	qw422016.N().S(`(1);vw(document.getElementById('ad_`)
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().S(`'),`)
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().S(`,`)
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().D(view.Duration())
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().S(`, v`)
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:47
+	qw422016.N().S(`);});});</script>`)
+//line private/templates/ad_layouts.qtpl:51
+}
+
+//line private/templates/ad_layouts.qtpl:51
+func (r *QTPLRenderer) writeadRenderIFrame(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_layouts.qtpl:51
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_layouts.qtpl:51
+	r.streamadRenderIFrame(qw422016, p, resp, it)
+//line private/templates/ad_layouts.qtpl:51
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_layouts.qtpl:51
+}
+
+//line private/templates/ad_layouts.qtpl:51
+func (r *QTPLRenderer) adRenderIFrame(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_layouts.qtpl:51
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_layouts.qtpl:51
+	r.writeadRenderIFrame(qb422016, p, resp, it)
+//line private/templates/ad_layouts.qtpl:51
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_layouts.qtpl:51
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_layouts.qtpl:51
+	return qs422016
+//line private/templates/ad_layouts.qtpl:51
+}
+
+// Render the lightweight HTML5 video player with media event tracking
+
+//line private/templates/ad_layouts.qtpl:55
+func (r *QTPLRenderer) streamadRenderVideo(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_layouts.qtpl:57
+	urlStr := r.URLGen.MustClickURL(it, resp)
+	asset := it.MainAsset()
+	adID := jsIdent(it.AdID())
+	view := r.viewRule(it)
+
+//line private/templates/ad_layouts.qtpl:62
+	streamadRenderLayoutCSS(qw422016, p)
+//line private/templates/ad_layouts.qtpl:62
+	qw422016.N().S(`<div id="ad_`)
+//line private/templates/ad_layouts.qtpl:63
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:63
+	qw422016.N().S(`" class="video-player"><video id="video_`)
+//line private/templates/ad_layouts.qtpl:64
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:64
+	qw422016.N().S(`" autoplay muted playsinline controls`)
+//line private/templates/ad_layouts.qtpl:64
+	if poster := videoPoster(it); poster != "" {
+//line private/templates/ad_layouts.qtpl:64
+		qw422016.N().S(`poster="`)
+//line private/templates/ad_layouts.qtpl:64
+		qw422016.E().S(safeURL(r.URLGen.CDNURL(poster)))
+//line private/templates/ad_layouts.qtpl:64
+		qw422016.N().S(`"`)
+//line private/templates/ad_layouts.qtpl:64
+	}
+//line private/templates/ad_layouts.qtpl:64
+	qw422016.N().S(`><source src="`)
+//line private/templates/ad_layouts.qtpl:65
+	qw422016.E().S(safeURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_layouts.qtpl:65
+	qw422016.N().S(`" type="`)
+//line private/templates/ad_layouts.qtpl:65
+	if asset.ContentType != "" {
+//line private/templates/ad_layouts.qtpl:65
+		qw422016.E().S(asset.ContentType)
+//line private/templates/ad_layouts.qtpl:65
+	} else {
+//line private/templates/ad_layouts.qtpl:65
+		qw422016.N().S(`video/mp4`)
+//line private/templates/ad_layouts.qtpl:65
+	}
+//line private/templates/ad_layouts.qtpl:65
+	qw422016.N().S(`" />`)
+//line private/templates/ad_layouts.qtpl:66
+	for _, thumb := range asset.Thumbs {
+//line private/templates/ad_layouts.qtpl:67
+		if thumb.IsVideo() {
+//line private/templates/ad_layouts.qtpl:67
+			qw422016.N().S(`<source src="`)
+//line private/templates/ad_layouts.qtpl:68
+			qw422016.E().S(safeURL(r.URLGen.CDNURL(thumb.URL)))
+//line private/templates/ad_layouts.qtpl:68
+			qw422016.N().S(`" type="video/mp4" />`)
+//line private/templates/ad_layouts.qtpl:69
+		}
+//line private/templates/ad_layouts.qtpl:70
+	}
+//line private/templates/ad_layouts.qtpl:70
+	qw422016.N().S(`</video><a target="_blank" href="`)
+//line private/templates/ad_layouts.qtpl:72
+	qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_layouts.qtpl:72
+	qw422016.N().S(`" class="cta">Learn more</a></div><script type="text/javascript"`)
+//line private/templates/ad_layouts.qtpl:74
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_layouts.qtpl:74
+	qw422016.N().S(`>(function(vd, ev){var once = {};var fire = function(name, repeat) {if (!repeat && once[name]) { return; }once[name] = 1;var links = ev[name] || [];for (var i = 0; i < links.length; i++) { (new Image()).src = links[i]; }};vd.addEventListener('loadeddata', function() {rd(function() {u`)
+//line private/templates/ad_layouts.qtpl:85
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:85
+	qw422016.N().S(`(1);vw(document.getElementById('ad_`)
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().S(`'),`)
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().S(`,`)
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().D(view.Duration())
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().S(`, v`)
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:86
+	qw422016.N().S(`);});});vd.addEventListener('error', function() { u`)
+//line private/templates/ad_layouts.qtpl:89
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:89
+	qw422016.N().S(`(0);v`)
+//line private/templates/ad_layouts.qtpl:89
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:89
+	qw422016.N().S(`(0); });vd.addEventListener('play', function() { if (once.start) { fire('resume', true); } else { fire('start'); } });vd.addEventListener('pause', function() { if (!vd.ended) { fire('pause', true); } });vd.addEventListener('volumechange', function() { fire(vd.muted ? 'mute' : 'unmute', true); });vd.addEventListener('timeupdate', function() {if (!vd.duration) { return; }var pos = vd.currentTime / vd.duration;if (pos >= 0.25) { fire('firstQuartile'); }if (pos >= 0.5) { fire('midpoint'); }if (pos >= 0.75) { fire('thirdQuartile'); }});vd.addEventListener('ended', function() { fire('complete'); });})(document.getElementById('video_`)
+//line private/templates/ad_layouts.qtpl:101
+	qw422016.N().S(adID)
+//line private/templates/ad_layouts.qtpl:101
+	qw422016.N().S(`'),`)
+//line private/templates/ad_layouts.qtpl:101
+	qw422016.N().S(r.videoEvents(p, it, resp))
+//line private/templates/ad_layouts.qtpl:101
+	qw422016.N().S(`);</script>`)
+//line private/templates/ad_layouts.qtpl:103
+}
+
+//line private/templates/ad_layouts.qtpl:103
+func (r *QTPLRenderer) writeadRenderVideo(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_layouts.qtpl:103
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_layouts.qtpl:103
+	r.streamadRenderVideo(qw422016, p, resp, it)
+//line private/templates/ad_layouts.qtpl:103
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_layouts.qtpl:103
+}
+
+//line private/templates/ad_layouts.qtpl:103
+func (r *QTPLRenderer) adRenderVideo(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_layouts.qtpl:103
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_layouts.qtpl:103
+	r.writeadRenderVideo(qb422016, p, resp, it)
+//line private/templates/ad_layouts.qtpl:103
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_layouts.qtpl:103
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_layouts.qtpl:103
+	return qs422016
+//line private/templates/ad_layouts.qtpl:103
+}
+
+//line private/templates/ad_layouts.qtpl:106
+func streamadRenderLayoutCSS(qw422016 *qt422016.Writer, p *Params) {
+//line private/templates/ad_layouts.qtpl:106
+	qw422016.N().S(`<style type="text/css"`)
+//line private/templates/ad_layouts.qtpl:107
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_layouts.qtpl:107
+	qw422016.N().S(`>.image-banner, .ad-frame, .video-player {display: block;width: 100%;height: 100%;overflow: hidden;}.image-banner img {display: block;width: 100%;height: 100%;object-fit: contain;}.video-player {position: relative;background: #000;}.video-player video {display: block;width: 100%;height: 100%;object-fit: contain;}.video-player .cta {position: absolute;top: 8px;right: 8px;padding: 4px 8px;border-radius: 3px;background: rgba(0,0,0,.6);color: #fff;font-family: Arial,Helvetica,sans-serif;font-size: 12px;text-decoration: none;}</style>`)
+//line private/templates/ad_layouts.qtpl:143
+}
+
+//line private/templates/ad_layouts.qtpl:143
+func writeadRenderLayoutCSS(qq422016 qtio422016.Writer, p *Params) {
+//line private/templates/ad_layouts.qtpl:143
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_layouts.qtpl:143
+	streamadRenderLayoutCSS(qw422016, p)
+//line private/templates/ad_layouts.qtpl:143
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_layouts.qtpl:143
+}
+
+//line private/templates/ad_layouts.qtpl:143
+func adRenderLayoutCSS(p *Params) string {
+//line private/templates/ad_layouts.qtpl:143
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_layouts.qtpl:143
+	writeadRenderLayoutCSS(qb422016, p)
+//line private/templates/ad_layouts.qtpl:143
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_layouts.qtpl:143
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_layouts.qtpl:143
+	return qs422016
+//line private/templates/ad_layouts.qtpl:143
+}
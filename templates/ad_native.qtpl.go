@@ -9,9 +9,10 @@ import (
 	"github.com/demdxx/gocast/v2"
 
 	"github.com/geniusrabbit/adcorelib/adtype"
+)
 
 //line private/templates/ad_native.qtpl:9
-
+import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
@@ -24,199 +25,485 @@ var (
 )
 
 //line private/templates/ad_native.qtpl:9
-func streamadRenderNative(qw422016 *qt422016.Writer, resp adtype.Response, it adtype.ResponseItem) {
+func (r *QTPLRenderer) streamadRenderNative(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
 //line private/templates/ad_native.qtpl:11
-	urlStr := URLGen.MustClickURL(it, resp)
+	urlStr := r.URLGen.MustClickURL(it, resp)
 	asset := it.MainAsset()
 	format := it.Format()
 	config := format.GetConfig()
+	adID := jsIdent(it.AdID())
+	view := r.viewRule(it)
+	theme := r.Themes.Theme(resp.Request())
 
-//line private/templates/ad_native.qtpl:16
-	streamadRenderNativeCSS(qw422016)
-//line private/templates/ad_native.qtpl:16
-	qw422016.N().S(`<div class="banner horizontal"><div class="image-wrap">`)
 //line private/templates/ad_native.qtpl:19
-	if asset.IsImage() {
+	streamadRenderNativeCSS(qw422016, p, theme)
 //line private/templates/ad_native.qtpl:19
-		qw422016.N().S(`<script type="text/javascript">var _qPixel = new Image();_qPixel.onload = function() { u`)
-//line private/templates/ad_native.qtpl:22
-		qw422016.N().S(it.AdID())
-//line private/templates/ad_native.qtpl:22
-		qw422016.N().S(`(1);v`)
+	qw422016.N().S(`<div id="banner_`)
+//line private/templates/ad_native.qtpl:20
+	qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:20
+	qw422016.N().S(`" class="banner`)
+//line private/templates/ad_native.qtpl:20
+	if theme.LabelPosition == LabelHorizontal {
+//line private/templates/ad_native.qtpl:20
+		qw422016.N().S(` `)
+//line private/templates/ad_native.qtpl:20
+		qw422016.N().S(`horizontal`)
+//line private/templates/ad_native.qtpl:20
+	}
+//line private/templates/ad_native.qtpl:20
+	qw422016.N().S(`"><div class="image-wrap">`)
 //line private/templates/ad_native.qtpl:22
-		qw422016.N().S(it.AdID())
+	if asset == nil {
 //line private/templates/ad_native.qtpl:22
-		qw422016.N().S(`(1); };_qPixel.onerror = function() { u`)
+		qw422016.N().S(`<script type="text/javascript"`)
 //line private/templates/ad_native.qtpl:23
-		qw422016.N().S(it.AdID())
-//line private/templates/ad_native.qtpl:23
-		qw422016.N().S(`(0);v`)
+		qw422016.N().S(p.nonceAttr())
 //line private/templates/ad_native.qtpl:23
-		qw422016.N().S(it.AdID())
-//line private/templates/ad_native.qtpl:23
-		qw422016.N().S(`(0); };_qPixel.src = '`)
-//line private/templates/ad_native.qtpl:24
-		qw422016.N().S(asset.URL)
-//line private/templates/ad_native.qtpl:24
-		qw422016.N().S(`';</script><a target="_blank" href="`)
+		qw422016.N().S(`>rd(function() {u`)
+//line private/templates/ad_native.qtpl:25
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:25
+		qw422016.N().S(`(1);vw(document.getElementById('banner_`)
 //line private/templates/ad_native.qtpl:26
-		qw422016.N().S(urlStr)
+		qw422016.N().S(adID)
 //line private/templates/ad_native.qtpl:26
-		qw422016.N().S(`" class="image" style="background-image: url(`)
+		qw422016.N().S(`'),`)
 //line private/templates/ad_native.qtpl:26
-		qw422016.N().S(asset.URL)
+		qw422016.N().F(view.VisibleRatio())
 //line private/templates/ad_native.qtpl:26
-		qw422016.N().S(`);"></a>`)
-//line private/templates/ad_native.qtpl:27
-	} else {
-//line private/templates/ad_native.qtpl:27
-		qw422016.N().S(`<a target="_blank" href="`)
-//line private/templates/ad_native.qtpl:28
-		qw422016.N().S(urlStr)
-//line private/templates/ad_native.qtpl:28
-		qw422016.N().S(`" class="video"><video onload="u`)
-//line private/templates/ad_native.qtpl:28
-		qw422016.N().S(it.AdID())
-//line private/templates/ad_native.qtpl:28
-		qw422016.N().S(`(1);v`)
-//line private/templates/ad_native.qtpl:28
-		qw422016.N().S(it.AdID())
-//line private/templates/ad_native.qtpl:28
-		qw422016.N().S(`(1)"onerror="u`)
+		qw422016.N().S(`,`)
+//line private/templates/ad_native.qtpl:26
+		qw422016.N().D(view.Duration())
+//line private/templates/ad_native.qtpl:26
+		qw422016.N().S(`, v`)
+//line private/templates/ad_native.qtpl:26
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:26
+		qw422016.N().S(`);});</script>`)
 //line private/templates/ad_native.qtpl:29
-		qw422016.N().S(it.AdID())
+	} else if asset.IsImage() {
 //line private/templates/ad_native.qtpl:29
+		qw422016.N().S(`<script type="text/javascript"`)
+//line private/templates/ad_native.qtpl:30
+		qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_native.qtpl:30
+		qw422016.N().S(`>var _qPixel = new Image();_qPixel.onload = function() {rd(function() {u`)
+//line private/templates/ad_native.qtpl:34
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:34
+		qw422016.N().S(`(1);vw(document.getElementById('banner_`)
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().S(`'),`)
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().S(`,`)
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().D(view.Duration())
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().S(`, v`)
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:35
+		qw422016.N().S(`);});};_qPixel.onerror = function() { u`)
+//line private/templates/ad_native.qtpl:38
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:38
 		qw422016.N().S(`(0);v`)
-//line private/templates/ad_native.qtpl:29
-		qw422016.N().S(it.AdID())
-//line private/templates/ad_native.qtpl:29
-		qw422016.N().S(`(0)"autoplay loop muted><source src="`)
-//line private/templates/ad_native.qtpl:31
-		qw422016.N().S(asset.URL)
-//line private/templates/ad_native.qtpl:31
+//line private/templates/ad_native.qtpl:38
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:38
+		qw422016.N().S(`(0); };_qPixel.src = '`)
+//line private/templates/ad_native.qtpl:39
+		qw422016.N().J(safeURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_native.qtpl:39
+		qw422016.N().S(`';</script><style type="text/css"`)
+//line private/templates/ad_native.qtpl:41
+		qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_native.qtpl:41
+		qw422016.N().S(`>.banner .image-`)
+//line private/templates/ad_native.qtpl:42
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:42
+		qw422016.N().S(`{ background-image: url('`)
+//line private/templates/ad_native.qtpl:42
+		qw422016.N().S(cssURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_native.qtpl:42
+		qw422016.N().S(`'); }</style><a target="_blank" href="`)
+//line private/templates/ad_native.qtpl:44
+		qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_native.qtpl:44
+		qw422016.N().S(`" class="image image-`)
+//line private/templates/ad_native.qtpl:44
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:44
+		qw422016.N().S(`"></a>`)
+//line private/templates/ad_native.qtpl:45
+	} else {
+//line private/templates/ad_native.qtpl:45
+		qw422016.N().S(`<a target="_blank" href="`)
+//line private/templates/ad_native.qtpl:46
+		qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_native.qtpl:46
+		qw422016.N().S(`" class="video"><video id="video_`)
+//line private/templates/ad_native.qtpl:46
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:46
+		qw422016.N().S(`" autoplay loop muted><source src="`)
+//line private/templates/ad_native.qtpl:47
+		qw422016.E().S(safeURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_native.qtpl:47
 		qw422016.N().S(`" type="`)
-//line private/templates/ad_native.qtpl:31
+//line private/templates/ad_native.qtpl:47
 		if asset.ContentType != "" {
-//line private/templates/ad_native.qtpl:31
-			qw422016.N().S(asset.ContentType)
-//line private/templates/ad_native.qtpl:31
+//line private/templates/ad_native.qtpl:47
+			qw422016.E().S(asset.ContentType)
+//line private/templates/ad_native.qtpl:47
 		} else {
-//line private/templates/ad_native.qtpl:31
+//line private/templates/ad_native.qtpl:47
 			qw422016.N().S(`video/mp4`)
-//line private/templates/ad_native.qtpl:31
+//line private/templates/ad_native.qtpl:47
 		}
-//line private/templates/ad_native.qtpl:31
+//line private/templates/ad_native.qtpl:47
 		qw422016.N().S(`" />`)
-//line private/templates/ad_native.qtpl:32
+//line private/templates/ad_native.qtpl:48
 		for _, thumb := range asset.Thumbs {
-//line private/templates/ad_native.qtpl:33
+//line private/templates/ad_native.qtpl:49
 			if thumb.IsVideo() {
-//line private/templates/ad_native.qtpl:33
+//line private/templates/ad_native.qtpl:49
 				qw422016.N().S(`<source src="`)
-//line private/templates/ad_native.qtpl:34
-				qw422016.N().S(thumb.URL)
-//line private/templates/ad_native.qtpl:34
-				qw422016.N().S(`" type="`)
-//line private/templates/ad_native.qtpl:34
-				qw422016.N().S(`video/mp4`)
-//line private/templates/ad_native.qtpl:34
-				qw422016.N().S(`" />`)
-//line private/templates/ad_native.qtpl:35
+//line private/templates/ad_native.qtpl:50
+				qw422016.E().S(safeURL(r.URLGen.CDNURL(thumb.URL)))
+//line private/templates/ad_native.qtpl:50
+				qw422016.N().S(`" type="video/mp4" />`)
+//line private/templates/ad_native.qtpl:51
 			}
-//line private/templates/ad_native.qtpl:36
+//line private/templates/ad_native.qtpl:52
 		}
-//line private/templates/ad_native.qtpl:36
-		qw422016.N().S(`Your browser does not support HTML5 video.</video></a>`)
-//line private/templates/ad_native.qtpl:39
+//line private/templates/ad_native.qtpl:52
+		qw422016.N().S(`Your browser does not support HTML5 video.</video></a><script type="text/javascript"`)
+//line private/templates/ad_native.qtpl:55
+		qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_native.qtpl:55
+		qw422016.N().S(`>(function(vd){vd.addEventListener('loadeddata', function() {rd(function() {u`)
+//line private/templates/ad_native.qtpl:59
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:59
+		qw422016.N().S(`(1);vw(document.getElementById('banner_`)
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().S(`'),`)
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().S(`,`)
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().D(view.Duration())
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().S(`, v`)
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:60
+		qw422016.N().S(`);});});vd.addEventListener('error', function() { u`)
+//line private/templates/ad_native.qtpl:63
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:63
+		qw422016.N().S(`(0);v`)
+//line private/templates/ad_native.qtpl:63
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:63
+		qw422016.N().S(`(0); });})(document.getElementById('video_`)
+//line private/templates/ad_native.qtpl:64
+		qw422016.N().S(adID)
+//line private/templates/ad_native.qtpl:64
+		qw422016.N().S(`'));</script>`)
+//line private/templates/ad_native.qtpl:66
 	}
-//line private/templates/ad_native.qtpl:39
+//line private/templates/ad_native.qtpl:66
 	qw422016.N().S(`</div><div class="label">`)
-//line private/templates/ad_native.qtpl:42
+//line private/templates/ad_native.qtpl:69
 	for _, field := range config.Fields {
-//line private/templates/ad_native.qtpl:43
+//line private/templates/ad_native.qtpl:70
 		if val := it.ContentItem(field.Name); val != nil {
-//line private/templates/ad_native.qtpl:44
+//line private/templates/ad_native.qtpl:71
 			if vl, _ := field.Prepare(it.ContentItem(field.Name)); vl != nil {
-//line private/templates/ad_native.qtpl:44
+//line private/templates/ad_native.qtpl:71
 				qw422016.N().S(`<a target="_blank" href="`)
-//line private/templates/ad_native.qtpl:45
-				qw422016.N().S(urlStr)
-//line private/templates/ad_native.qtpl:45
+//line private/templates/ad_native.qtpl:72
+				qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_native.qtpl:72
 				qw422016.N().S(`" class="`)
-//line private/templates/ad_native.qtpl:45
-				qw422016.N().S(field.Name)
-//line private/templates/ad_native.qtpl:45
+//line private/templates/ad_native.qtpl:72
+				qw422016.E().S(field.Name)
+//line private/templates/ad_native.qtpl:72
 				qw422016.N().S(`">`)
-//line private/templates/ad_native.qtpl:46
-				qw422016.N().S(gocast.Str(vl))
-//line private/templates/ad_native.qtpl:46
+//line private/templates/ad_native.qtpl:73
+				qw422016.E().S(gocast.Str(vl))
+//line private/templates/ad_native.qtpl:73
 				qw422016.N().S(`</a>`)
-//line private/templates/ad_native.qtpl:48
+//line private/templates/ad_native.qtpl:75
 			}
-//line private/templates/ad_native.qtpl:49
+//line private/templates/ad_native.qtpl:76
 		}
-//line private/templates/ad_native.qtpl:50
+//line private/templates/ad_native.qtpl:77
 	}
-//line private/templates/ad_native.qtpl:50
+//line private/templates/ad_native.qtpl:77
 	qw422016.N().S(`</div></div>`)
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
 }
 
-//line private/templates/ad_native.qtpl:53
-func writeadRenderNative(qq422016 qtio422016.Writer, resp adtype.Response, it adtype.ResponseItem) {
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
+func (r *QTPLRenderer) writeadRenderNative(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_native.qtpl:80
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_native.qtpl:53
-	streamadRenderNative(qw422016, resp, it)
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
+	r.streamadRenderNative(qw422016, p, resp, it)
+//line private/templates/ad_native.qtpl:80
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
 }
 
-//line private/templates/ad_native.qtpl:53
-func adRenderNative(resp adtype.Response, it adtype.ResponseItem) string {
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
+func (r *QTPLRenderer) adRenderNative(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_native.qtpl:80
 	qb422016 := qt422016.AcquireByteBuffer()
-//line private/templates/ad_native.qtpl:53
-	writeadRenderNative(qb422016, resp, it)
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
+	r.writeadRenderNative(qb422016, p, resp, it)
+//line private/templates/ad_native.qtpl:80
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
 	return qs422016
-//line private/templates/ad_native.qtpl:53
+//line private/templates/ad_native.qtpl:80
 }
 
-//line private/templates/ad_native.qtpl:56
-func streamadRenderNativeCSS(qw422016 *qt422016.Writer) {
-//line private/templates/ad_native.qtpl:56
-	qw422016.N().S(`<style type="text/css">html, body {padding: 0;margin: 0;height: 100%;box-sizing: border-box;}.banner {font-family: Arial,Helvetica,sans-serif;background: #fff;overflow: hidden;height: 100%;position: relative;padding-bottom: 83px;-webkit-box-sizing: border-box;-moz-box-sizing: border-box;box-sizing: border-box;}.banner .label {padding: 2px 5px;box-sizing: border-box;height: 83px;position: absolute;bottom: 0;left: 0;right: 0;}.banner .label a {text-decoration: none!important;word-wrap: break-word;overflow: hidden;background-image: none;-webkit-box-sizing: content-box;-moz-box-sizing: content-box;box-sizing: content-box;display: block;}.banner .label a:hover {color: #35327b;}.banner .label .title,.banner .label .description {font-size: 14px;font-weight: 400;line-height: 1.3em;max-height: 65px;color: #000;}.banner .label .brand {font-size: 11px;font-weight: 700;line-height: 1em;max-height: 22px;color: #999;padding: 3px 0 0;}.banner .brandname {font-size: 11px;font-weight: 700;line-height: 1em;max-height: 22px;color: #999;padding: 3px 0 0;}.banner .phone {font-size: 11px;font-weight: 700;line-height: 1em;max-height: 22px;color: #699;padding: 3px 0 0;}.banner .image {border-style: none;-moz-border-radius: 0;-webkit-border-radius: 0;border-radius: 0;border-width: 0;background-color: #eee;height: 100%;-webkit-box-sizing: border-box;-moz-box-sizing: border-box;box-sizing: border-box;background-size: cover;background-position: center center;background-repeat: no-repeat;display: block;margin: 0;}@media screen and (min-aspect-ratio: 10/7) {.banner .image{width: 40%;float: left;}.banner .label{width: 60%;float: left;position: static;}.banner {padding: 0;}}/* === horizontal orientation === */.banner.horizontal {padding: 0;}.banner.horizontal .image{width: 40%;float: left;}.banner.horizontal .label{width: 60%;float: left;position: static;}</style>`)
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:83
+func streamadRenderNativeCSS(qw422016 *qt422016.Writer, p *Params, th *Theme) {
+//line private/templates/ad_native.qtpl:83
+	qw422016.N().S(`<style type="text/css"`)
+//line private/templates/ad_native.qtpl:84
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_native.qtpl:84
+	qw422016.N().S(`>html, body {padding: 0;margin: 0;height: 100%;box-sizing: border-box;}.banner {font-family:`)
+//line private/templates/ad_native.qtpl:92
+	qw422016.N().S(th.FontFamily)
+//line private/templates/ad_native.qtpl:92
+	qw422016.N().S(`;overflow: hidden;height: 100%;position: relative;padding-bottom:`)
+//line private/templates/ad_native.qtpl:96
+	qw422016.N().D(th.LabelHeight)
+//line private/templates/ad_native.qtpl:96
+	qw422016.N().S(`px;-webkit-box-sizing: border-box;-moz-box-sizing: border-box;box-sizing: border-box;}.banner .label {padding: 2px`)
+//line private/templates/ad_native.qtpl:102
+	qw422016.N().D(th.Spacing)
+//line private/templates/ad_native.qtpl:102
+	qw422016.N().S(`px;box-sizing: border-box;height:`)
+//line private/templates/ad_native.qtpl:104
+	qw422016.N().D(th.LabelHeight)
+//line private/templates/ad_native.qtpl:104
+	qw422016.N().S(`px;position: absolute;bottom: 0;left: 0;right: 0;}.banner .label a {text-decoration: none!important;word-wrap: break-word;overflow: hidden;background-image: none;-webkit-box-sizing: content-box;-moz-box-sizing: content-box;box-sizing: content-box;display: block;}.banner .label .title,.banner .label .description {font-size:`)
+//line private/templates/ad_native.qtpl:121
+	qw422016.N().D(th.FontSize)
+//line private/templates/ad_native.qtpl:121
+	qw422016.N().S(`px;font-weight: 400;line-height: 1.3em;max-height: 65px;}.banner .label .brand, .banner .brandname, .banner .phone {font-size: 11px;font-weight: 700;line-height: 1em;max-height: 22px;padding: 3px 0 0;}.banner .image {border-style: none;-moz-border-radius: 0;-webkit-border-radius: 0;border-radius: 0;border-width: 0;height: 100%;-webkit-box-sizing: border-box;-moz-box-sizing: border-box;box-sizing: border-box;background-size: cover;background-position: center center;background-repeat: no-repeat;display: block;margin: 0;}`)
+//line private/templates/ad_native.qtpl:149
+	streamadRenderNativeColorsCSS(qw422016, th.Colors)
+//line private/templates/ad_native.qtpl:150
+	switch th.LabelPosition {
+//line private/templates/ad_native.qtpl:151
+	case LabelHorizontal:
+//line private/templates/ad_native.qtpl:152
+		streamadRenderNativeSideCSS(qw422016, ".banner.horizontal", th)
+//line private/templates/ad_native.qtpl:153
+	case LabelRight, LabelLeft:
+//line private/templates/ad_native.qtpl:154
+		streamadRenderNativeSideCSS(qw422016, ".banner", th)
+//line private/templates/ad_native.qtpl:155
+	case LabelAuto:
+//line private/templates/ad_native.qtpl:155
+		qw422016.N().S(`@media screen and (min-aspect-ratio: 10/7) {`)
+//line private/templates/ad_native.qtpl:157
+		streamadRenderNativeSideCSS(qw422016, ".banner", th)
+//line private/templates/ad_native.qtpl:157
+		qw422016.N().S(`}`)
+//line private/templates/ad_native.qtpl:159
+	}
+//line private/templates/ad_native.qtpl:160
+	if th.Dark != nil {
+//line private/templates/ad_native.qtpl:160
+		qw422016.N().S(`@media (prefers-color-scheme: dark) {`)
+//line private/templates/ad_native.qtpl:162
+		streamadRenderNativeColorsCSS(qw422016, *th.Dark)
+//line private/templates/ad_native.qtpl:162
+		qw422016.N().S(`}`)
+//line private/templates/ad_native.qtpl:164
+	}
+//line private/templates/ad_native.qtpl:164
+	qw422016.N().S(`</style>`)
+//line private/templates/ad_native.qtpl:166
 }
 
-//line private/templates/ad_native.qtpl:173
-func writeadRenderNativeCSS(qq422016 qtio422016.Writer) {
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:166
+func writeadRenderNativeCSS(qq422016 qtio422016.Writer, p *Params, th *Theme) {
+//line private/templates/ad_native.qtpl:166
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line private/templates/ad_native.qtpl:173
-	streamadRenderNativeCSS(qw422016)
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:166
+	streamadRenderNativeCSS(qw422016, p, th)
+//line private/templates/ad_native.qtpl:166
 	qt422016.ReleaseWriter(qw422016)
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:166
 }
 
-//line private/templates/ad_native.qtpl:173
-func adRenderNativeCSS() string {
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:166
+func adRenderNativeCSS(p *Params, th *Theme) string {
+//line private/templates/ad_native.qtpl:166
 	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_native.qtpl:166
+	writeadRenderNativeCSS(qb422016, p, th)
+//line private/templates/ad_native.qtpl:166
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_native.qtpl:166
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_native.qtpl:166
+	return qs422016
+//line private/templates/ad_native.qtpl:166
+}
+
+//line private/templates/ad_native.qtpl:169
+func streamadRenderNativeColorsCSS(qw422016 *qt422016.Writer, c ThemeColors) {
+//line private/templates/ad_native.qtpl:169
+	qw422016.N().S(`.banner { background:`)
+//line private/templates/ad_native.qtpl:170
+	qw422016.N().S(c.Background)
+//line private/templates/ad_native.qtpl:170
+	qw422016.N().S(`; }.banner .label .title { color:`)
+//line private/templates/ad_native.qtpl:171
+	qw422016.N().S(c.Title)
+//line private/templates/ad_native.qtpl:171
+	qw422016.N().S(`; }.banner .label .description { color:`)
+//line private/templates/ad_native.qtpl:172
+	qw422016.N().S(c.Text)
+//line private/templates/ad_native.qtpl:172
+	qw422016.N().S(`; }.banner .label a:hover { color:`)
 //line private/templates/ad_native.qtpl:173
-	writeadRenderNativeCSS(qb422016)
+	qw422016.N().S(c.Hover)
 //line private/templates/ad_native.qtpl:173
+	qw422016.N().S(`; }.banner .label .brand, .banner .brandname, .banner .phone { color:`)
+//line private/templates/ad_native.qtpl:174
+	qw422016.N().S(c.Brand)
+//line private/templates/ad_native.qtpl:174
+	qw422016.N().S(`; }.banner .image { background-color:`)
+//line private/templates/ad_native.qtpl:175
+	qw422016.N().S(c.Image)
+//line private/templates/ad_native.qtpl:175
+	qw422016.N().S(`; }`)
+//line private/templates/ad_native.qtpl:176
+}
+
+//line private/templates/ad_native.qtpl:176
+func writeadRenderNativeColorsCSS(qq422016 qtio422016.Writer, c ThemeColors) {
+//line private/templates/ad_native.qtpl:176
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_native.qtpl:176
+	streamadRenderNativeColorsCSS(qw422016, c)
+//line private/templates/ad_native.qtpl:176
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_native.qtpl:176
+}
+
+//line private/templates/ad_native.qtpl:176
+func adRenderNativeColorsCSS(c ThemeColors) string {
+//line private/templates/ad_native.qtpl:176
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_native.qtpl:176
+	writeadRenderNativeColorsCSS(qb422016, c)
+//line private/templates/ad_native.qtpl:176
 	qs422016 := string(qb422016.B)
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:176
 	qt422016.ReleaseByteBuffer(qb422016)
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:176
 	return qs422016
-//line private/templates/ad_native.qtpl:173
+//line private/templates/ad_native.qtpl:176
+}
+
+// Layout with the image and the label side by side, the selector is `.banner`
+// or `.banner.horizontal` of the classic layout
+
+//line private/templates/ad_native.qtpl:181
+func streamadRenderNativeSideCSS(qw422016 *qt422016.Writer, sel string, th *Theme) {
+//line private/templates/ad_native.qtpl:182
+	qw422016.N().S(sel)
+//line private/templates/ad_native.qtpl:182
+	qw422016.N().S(`{padding: 0;}`)
+//line private/templates/ad_native.qtpl:185
+	qw422016.N().S(sel)
+//line private/templates/ad_native.qtpl:185
+	qw422016.N().S(`.image {width:`)
+//line private/templates/ad_native.qtpl:186
+	qw422016.N().D(th.imagePercent())
+//line private/templates/ad_native.qtpl:186
+	qw422016.N().S(`%;float:`)
+//line private/templates/ad_native.qtpl:187
+	if th.LabelPosition == LabelLeft {
+//line private/templates/ad_native.qtpl:187
+		qw422016.N().S(`right`)
+//line private/templates/ad_native.qtpl:187
+	} else {
+//line private/templates/ad_native.qtpl:187
+		qw422016.N().S(`left`)
+//line private/templates/ad_native.qtpl:187
+	}
+//line private/templates/ad_native.qtpl:187
+	qw422016.N().S(`;}`)
+//line private/templates/ad_native.qtpl:189
+	qw422016.N().S(sel)
+//line private/templates/ad_native.qtpl:189
+	qw422016.N().S(`.label {width:`)
+//line private/templates/ad_native.qtpl:190
+	qw422016.N().D(100 - th.imagePercent())
+//line private/templates/ad_native.qtpl:190
+	qw422016.N().S(`%;height: 100%;float:`)
+//line private/templates/ad_native.qtpl:192
+	if th.LabelPosition == LabelLeft {
+//line private/templates/ad_native.qtpl:192
+		qw422016.N().S(`right`)
+//line private/templates/ad_native.qtpl:192
+	} else {
+//line private/templates/ad_native.qtpl:192
+		qw422016.N().S(`left`)
+//line private/templates/ad_native.qtpl:192
+	}
+//line private/templates/ad_native.qtpl:192
+	qw422016.N().S(`;position: static;}`)
+//line private/templates/ad_native.qtpl:195
+}
+
+//line private/templates/ad_native.qtpl:195
+func writeadRenderNativeSideCSS(qq422016 qtio422016.Writer, sel string, th *Theme) {
+//line private/templates/ad_native.qtpl:195
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_native.qtpl:195
+	streamadRenderNativeSideCSS(qw422016, sel, th)
+//line private/templates/ad_native.qtpl:195
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_native.qtpl:195
+}
+
+//line private/templates/ad_native.qtpl:195
+func adRenderNativeSideCSS(sel string, th *Theme) string {
+//line private/templates/ad_native.qtpl:195
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_native.qtpl:195
+	writeadRenderNativeSideCSS(qb422016, sel, th)
+//line private/templates/ad_native.qtpl:195
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_native.qtpl:195
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_native.qtpl:195
+	return qs422016
+//line private/templates/ad_native.qtpl:195
 }
@@ -0,0 +1,145 @@
+// Code generated by qtc from "ad_overlay.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// Render the script of the interstitial or sticky unit with the ad document in the sandboxed frame
+
+//line private/templates/ad_overlay.qtpl:2
+package templates
+
+//line private/templates/ad_overlay.qtpl:2
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line private/templates/ad_overlay.qtpl:2
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line private/templates/ad_overlay.qtpl:2
+func StreamAdRenderOverlayScript(qw422016 *qt422016.Writer, unit *Overlay, zoneID uint64, doc, closeURL string) {
+//line private/templates/ad_overlay.qtpl:2
+	qw422016.N().S(`!(function(){var zone = '`)
+//line private/templates/ad_overlay.qtpl:4
+	qw422016.N().DUL(zoneID)
+//line private/templates/ad_overlay.qtpl:4
+	qw422016.N().S(`';var cap =`)
+//line private/templates/ad_overlay.qtpl:5
+	qw422016.N().D(unit.FrequencyCap)
+//line private/templates/ad_overlay.qtpl:5
+	qw422016.N().S(`, period =`)
+//line private/templates/ad_overlay.qtpl:5
+	qw422016.N().D(unit.FrequencyPeriod)
+//line private/templates/ad_overlay.qtpl:5
+	qw422016.N().S(`* 1000;var capped = function() {if (!cap) { return false; }try {var key = 'adcap_' + zone, now = Date.now();var list = JSON.parse(window.localStorage.getItem(key) || '[]').filter(function(t) { return now - t < period; });if (list.length >= cap) { return true; }list.push(now);window.localStorage.setItem(key, JSON.stringify(list));} catch (err) {}return false;};var show = function() {if (capped()) { return; }var box = document.createElement('div');var frame = document.createElement('iframe');var btn = document.createElement('button');var closed = false, left =`)
+//line private/templates/ad_overlay.qtpl:22
+	qw422016.N().D(unit.CloseDelay)
+//line private/templates/ad_overlay.qtpl:22
+	qw422016.N().S(`;var close = function() {if (closed) { return; }closed = true;box.parentNode && box.parentNode.removeChild(box);`)
+//line private/templates/ad_overlay.qtpl:27
+	if closeURL != "" {
+//line private/templates/ad_overlay.qtpl:27
+		qw422016.N().S(`(new Image()).src = '`)
+//line private/templates/ad_overlay.qtpl:27
+		qw422016.N().J(closeURL)
+//line private/templates/ad_overlay.qtpl:27
+		qw422016.N().S(`';`)
+//line private/templates/ad_overlay.qtpl:27
+	}
+//line private/templates/ad_overlay.qtpl:27
+	qw422016.N().S(`};box.style.position = 'fixed';box.style.left = '0';box.style.right = '0';box.style.zIndex = '`)
+//line private/templates/ad_overlay.qtpl:32
+	qw422016.N().D(unit.ZIndex)
+//line private/templates/ad_overlay.qtpl:32
+	qw422016.N().S(`';`)
+//line private/templates/ad_overlay.qtpl:33
+	if unit.IsSticky() {
+//line private/templates/ad_overlay.qtpl:33
+		qw422016.N().S(`box.style.`)
+//line private/templates/ad_overlay.qtpl:34
+		qw422016.N().S(unit.Position)
+//line private/templates/ad_overlay.qtpl:34
+		qw422016.N().S(`= '0';box.style.height = '`)
+//line private/templates/ad_overlay.qtpl:35
+		qw422016.N().D(unit.Height)
+//line private/templates/ad_overlay.qtpl:35
+		qw422016.N().S(`px';box.style.background = '#fff';box.style.boxShadow = '0 0 6px rgba(0,0,0,.3)';`)
+//line private/templates/ad_overlay.qtpl:38
+	} else {
+//line private/templates/ad_overlay.qtpl:38
+		qw422016.N().S(`box.style.top = '0';box.style.bottom = '0';box.style.background = 'rgba(0,0,0,.85)';`)
+//line private/templates/ad_overlay.qtpl:42
+	}
+//line private/templates/ad_overlay.qtpl:42
+	qw422016.N().S(`frame.setAttribute('sandbox', 'allow-scripts allow-popups allow-popups-to-escape-sandbox');frame.setAttribute('scrolling', 'no');frame.style.border = '0';frame.style.width = '100%';frame.style.height = '100%';frame.srcdoc = '`)
+//line private/templates/ad_overlay.qtpl:48
+	qw422016.N().J(doc)
+//line private/templates/ad_overlay.qtpl:48
+	qw422016.N().S(`';btn.setAttribute('type', 'button');btn.setAttribute('aria-label', 'Close');btn.style.position = 'absolute';btn.style.right = '4px';btn.style.`)
+//line private/templates/ad_overlay.qtpl:53
+	if unit.IsSticky() && unit.Position == OverlayTop {
+//line private/templates/ad_overlay.qtpl:53
+		qw422016.N().S(`bottom`)
+//line private/templates/ad_overlay.qtpl:53
+	} else {
+//line private/templates/ad_overlay.qtpl:53
+		qw422016.N().S(`top`)
+//line private/templates/ad_overlay.qtpl:53
+	}
+//line private/templates/ad_overlay.qtpl:53
+	qw422016.N().S(`= '`)
+//line private/templates/ad_overlay.qtpl:53
+	if unit.IsSticky() {
+//line private/templates/ad_overlay.qtpl:53
+		qw422016.N().S(`-28px`)
+//line private/templates/ad_overlay.qtpl:53
+	} else {
+//line private/templates/ad_overlay.qtpl:53
+		qw422016.N().S(`8px`)
+//line private/templates/ad_overlay.qtpl:53
+	}
+//line private/templates/ad_overlay.qtpl:53
+	qw422016.N().S(`';btn.style.width = '28px';btn.style.height = '28px';btn.style.border = '0';btn.style.borderRadius = '14px';btn.style.background = 'rgba(0,0,0,.6)';btn.style.color = '#fff';btn.style.font = '14px Arial,sans-serif';btn.style.cursor = 'pointer';var tick = function() {if (left > 0) {btn.textContent = String(left--);btn.disabled = true;setTimeout(tick, 1000);} else {btn.textContent = '×';btn.disabled = false;}};btn.addEventListener('click', close);tick();`)
+//line private/templates/ad_overlay.qtpl:74
+	if unit.AutoClose > 0 {
+//line private/templates/ad_overlay.qtpl:74
+		qw422016.N().S(`setTimeout(close,`)
+//line private/templates/ad_overlay.qtpl:74
+		qw422016.N().D(unit.AutoClose)
+//line private/templates/ad_overlay.qtpl:74
+		qw422016.N().S(`* 1000);`)
+//line private/templates/ad_overlay.qtpl:74
+	}
+//line private/templates/ad_overlay.qtpl:74
+	qw422016.N().S(`box.appendChild(frame);box.appendChild(btn);document.body.appendChild(box);};if (document.body) { show(); } else { document.addEventListener('DOMContentLoaded', show); }})();`)
+//line private/templates/ad_overlay.qtpl:81
+}
+
+//line private/templates/ad_overlay.qtpl:81
+func WriteAdRenderOverlayScript(qq422016 qtio422016.Writer, unit *Overlay, zoneID uint64, doc, closeURL string) {
+//line private/templates/ad_overlay.qtpl:81
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_overlay.qtpl:81
+	StreamAdRenderOverlayScript(qw422016, unit, zoneID, doc, closeURL)
+//line private/templates/ad_overlay.qtpl:81
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_overlay.qtpl:81
+}
+
+//line private/templates/ad_overlay.qtpl:81
+func AdRenderOverlayScript(unit *Overlay, zoneID uint64, doc, closeURL string) string {
+//line private/templates/ad_overlay.qtpl:81
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_overlay.qtpl:81
+	WriteAdRenderOverlayScript(qb422016, unit, zoneID, doc, closeURL)
+//line private/templates/ad_overlay.qtpl:81
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_overlay.qtpl:81
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_overlay.qtpl:81
+	return qs422016
+//line private/templates/ad_overlay.qtpl:81
+}
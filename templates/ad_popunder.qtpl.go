@@ -0,0 +1,113 @@
+// Code generated by qtc from "ad_popunder.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// Render the script which opens the direct URL on the first click of the user
+
+//line private/templates/ad_popunder.qtpl:2
+package templates
+
+//line private/templates/ad_popunder.qtpl:2
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line private/templates/ad_popunder.qtpl:2
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line private/templates/ad_popunder.qtpl:2
+func StreamAdRenderPopunderScript(qw422016 *qt422016.Writer, tag *Popunder, zoneID uint64, directURL, loadURL, triggerURL string) {
+//line private/templates/ad_popunder.qtpl:2
+	qw422016.N().S(`!(function(){var key = '_sc`)
+//line private/templates/ad_popunder.qtpl:4
+	qw422016.N().DUL(zoneID)
+//line private/templates/ad_popunder.qtpl:4
+	qw422016.N().S(`';var px = function(u) { if (u) { (new Image()).src = u; } };var state = function() {try { return JSON.parse(window.sessionStorage.getItem(key) || '{"n":0,"t":0}'); } catch (err) { return {n: 0, t: 0}; }};var allowed = function(st) {return st.n <`)
+//line private/templates/ad_popunder.qtpl:10
+	qw422016.N().D(tag.SessionCap)
+//line private/templates/ad_popunder.qtpl:10
+	qw422016.N().S(`&& Date.now() - st.t >=`)
+//line private/templates/ad_popunder.qtpl:10
+	qw422016.N().D(tag.Interval)
+//line private/templates/ad_popunder.qtpl:10
+	qw422016.N().S(`* 1000;};px('`)
+//line private/templates/ad_popunder.qtpl:12
+	qw422016.N().J(loadURL)
+//line private/templates/ad_popunder.qtpl:12
+	qw422016.N().S(`');if (!allowed(state())) { return; }var handler = function(ev) {var st = state();if (!allowed(st)) {if (st.n >=`)
+//line private/templates/ad_popunder.qtpl:17
+	qw422016.N().D(tag.SessionCap)
+//line private/templates/ad_popunder.qtpl:17
+	qw422016.N().S(`) { document.removeEventListener('click', handler, true); }return;}var url = '`)
+//line private/templates/ad_popunder.qtpl:20
+	qw422016.N().J(directURL)
+//line private/templates/ad_popunder.qtpl:20
+	qw422016.N().S(`';var win = window.open(`)
+//line private/templates/ad_popunder.qtpl:21
+	if tag.Mode == TabunderMode {
+//line private/templates/ad_popunder.qtpl:21
+		qw422016.N().S(`window.location.href`)
+//line private/templates/ad_popunder.qtpl:21
+	} else {
+//line private/templates/ad_popunder.qtpl:21
+		qw422016.N().S(`url`)
+//line private/templates/ad_popunder.qtpl:21
+	}
+//line private/templates/ad_popunder.qtpl:21
+	qw422016.N().S(`, '_blank');if (!win) { return; }`)
+//line private/templates/ad_popunder.qtpl:23
+	if tag.Mode == PopunderMode {
+//line private/templates/ad_popunder.qtpl:23
+		qw422016.N().S(`try { win.blur(); window.focus(); } catch (err) {}`)
+//line private/templates/ad_popunder.qtpl:25
+	}
+//line private/templates/ad_popunder.qtpl:25
+	qw422016.N().S(`st.n++;st.t = Date.now();try { window.sessionStorage.setItem(key, JSON.stringify(st)); } catch (err) {}px('`)
+//line private/templates/ad_popunder.qtpl:29
+	qw422016.N().J(triggerURL)
+//line private/templates/ad_popunder.qtpl:29
+	qw422016.N().S(`');if (st.n >=`)
+//line private/templates/ad_popunder.qtpl:30
+	qw422016.N().D(tag.SessionCap)
+//line private/templates/ad_popunder.qtpl:30
+	qw422016.N().S(`) { document.removeEventListener('click', handler, true); }`)
+//line private/templates/ad_popunder.qtpl:31
+	if tag.Mode == TabunderMode {
+//line private/templates/ad_popunder.qtpl:31
+		qw422016.N().S(`setTimeout(function() { window.location.href = url; }, 100);`)
+//line private/templates/ad_popunder.qtpl:33
+	}
+//line private/templates/ad_popunder.qtpl:33
+	qw422016.N().S(`};document.addEventListener('click', handler, true);})();`)
+//line private/templates/ad_popunder.qtpl:37
+}
+
+//line private/templates/ad_popunder.qtpl:37
+func WriteAdRenderPopunderScript(qq422016 qtio422016.Writer, tag *Popunder, zoneID uint64, directURL, loadURL, triggerURL string) {
+//line private/templates/ad_popunder.qtpl:37
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_popunder.qtpl:37
+	StreamAdRenderPopunderScript(qw422016, tag, zoneID, directURL, loadURL, triggerURL)
+//line private/templates/ad_popunder.qtpl:37
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_popunder.qtpl:37
+}
+
+//line private/templates/ad_popunder.qtpl:37
+func AdRenderPopunderScript(tag *Popunder, zoneID uint64, directURL, loadURL, triggerURL string) string {
+//line private/templates/ad_popunder.qtpl:37
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_popunder.qtpl:37
+	WriteAdRenderPopunderScript(qb422016, tag, zoneID, directURL, loadURL, triggerURL)
+//line private/templates/ad_popunder.qtpl:37
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_popunder.qtpl:37
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_popunder.qtpl:37
+	return qs422016
+//line private/templates/ad_popunder.qtpl:37
+}
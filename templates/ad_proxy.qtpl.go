@@ -0,0 +1,238 @@
+// Code generated by qtc from "ad_proxy.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line private/templates/ad_proxy.qtpl:2
+package templates
+
+//line private/templates/ad_proxy.qtpl:2
+import (
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Render the winning item of the auction on the server side
+
+//line private/templates/ad_proxy.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line private/templates/ad_proxy.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line private/templates/ad_proxy.qtpl:8
+func (r *QTPLRenderer) StreamAdRenderProxyBanner(qw422016 *qt422016.Writer, p *Params, resp adtype.Response) {
+//line private/templates/ad_proxy.qtpl:9
+	streamadHeader(qw422016, p)
+//line private/templates/ad_proxy.qtpl:10
+	if it := FirstResponseItem(resp); it != nil {
+//line private/templates/ad_proxy.qtpl:11
+		r.streamadPixelItem(qw422016, p, it, resp)
+//line private/templates/ad_proxy.qtpl:12
+		r.streamadRenderItemBody(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:13
+	}
+//line private/templates/ad_proxy.qtpl:14
+	streamadFooter(qw422016)
+//line private/templates/ad_proxy.qtpl:15
+}
+
+//line private/templates/ad_proxy.qtpl:15
+func (r *QTPLRenderer) WriteAdRenderProxyBanner(qq422016 qtio422016.Writer, p *Params, resp adtype.Response) {
+//line private/templates/ad_proxy.qtpl:15
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_proxy.qtpl:15
+	r.StreamAdRenderProxyBanner(qw422016, p, resp)
+//line private/templates/ad_proxy.qtpl:15
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_proxy.qtpl:15
+}
+
+//line private/templates/ad_proxy.qtpl:15
+func (r *QTPLRenderer) AdRenderProxyBanner(p *Params, resp adtype.Response) string {
+//line private/templates/ad_proxy.qtpl:15
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_proxy.qtpl:15
+	r.WriteAdRenderProxyBanner(qb422016, p, resp)
+//line private/templates/ad_proxy.qtpl:15
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_proxy.qtpl:15
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_proxy.qtpl:15
+	return qs422016
+//line private/templates/ad_proxy.qtpl:15
+}
+
+// Render the empty document if there is no ads
+
+//line private/templates/ad_proxy.qtpl:19
+func StreamAdRenderEmptyBanner(qw422016 *qt422016.Writer, p *Params) {
+//line private/templates/ad_proxy.qtpl:20
+	streamadHeader(qw422016, p)
+//line private/templates/ad_proxy.qtpl:21
+	streamadFooter(qw422016)
+//line private/templates/ad_proxy.qtpl:22
+}
+
+//line private/templates/ad_proxy.qtpl:22
+func WriteAdRenderEmptyBanner(qq422016 qtio422016.Writer, p *Params) {
+//line private/templates/ad_proxy.qtpl:22
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_proxy.qtpl:22
+	StreamAdRenderEmptyBanner(qw422016, p)
+//line private/templates/ad_proxy.qtpl:22
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_proxy.qtpl:22
+}
+
+//line private/templates/ad_proxy.qtpl:22
+func AdRenderEmptyBanner(p *Params) string {
+//line private/templates/ad_proxy.qtpl:22
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_proxy.qtpl:22
+	WriteAdRenderEmptyBanner(qb422016, p)
+//line private/templates/ad_proxy.qtpl:22
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_proxy.qtpl:22
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_proxy.qtpl:22
+	return qs422016
+//line private/templates/ad_proxy.qtpl:22
+}
+
+// Render the single item markup without document wrapper
+
+//line private/templates/ad_proxy.qtpl:26
+func (r *QTPLRenderer) StreamAdRenderItem(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_proxy.qtpl:27
+	streamadActionScript(qw422016, p)
+//line private/templates/ad_proxy.qtpl:28
+	r.streamadPixelItem(qw422016, p, it, resp)
+//line private/templates/ad_proxy.qtpl:29
+	r.streamadRenderItemBody(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:30
+}
+
+//line private/templates/ad_proxy.qtpl:30
+func (r *QTPLRenderer) WriteAdRenderItem(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_proxy.qtpl:30
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_proxy.qtpl:30
+	r.StreamAdRenderItem(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:30
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_proxy.qtpl:30
+}
+
+//line private/templates/ad_proxy.qtpl:30
+func (r *QTPLRenderer) AdRenderItem(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_proxy.qtpl:30
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_proxy.qtpl:30
+	r.WriteAdRenderItem(qb422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:30
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_proxy.qtpl:30
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_proxy.qtpl:30
+	return qs422016
+//line private/templates/ad_proxy.qtpl:30
+}
+
+// Render the item by the layout of its format: the trusted HTML snippet of the item
+// (the only unescaped output), the frame, the video player, the image banner or the native layout
+
+//line private/templates/ad_proxy.qtpl:35
+func (r *QTPLRenderer) streamadRenderItemBody(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_proxy.qtpl:36
+	switch ItemLayout(it) {
+//line private/templates/ad_proxy.qtpl:37
+	case LayoutHTML:
+//line private/templates/ad_proxy.qtpl:39
+		adID := jsIdent(it.AdID())
+		view := r.viewRule(it)
+
+//line private/templates/ad_proxy.qtpl:41
+		qw422016.N().S(`<div id="ad_`)
+//line private/templates/ad_proxy.qtpl:42
+		qw422016.N().S(adID)
+//line private/templates/ad_proxy.qtpl:42
+		qw422016.N().S(`">`)
+//line private/templates/ad_proxy.qtpl:42
+		qw422016.N().S(p.nonceHTML(trustedContent(it)))
+//line private/templates/ad_proxy.qtpl:42
+		qw422016.N().S(`</div><script type="text/javascript"`)
+//line private/templates/ad_proxy.qtpl:43
+		qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_proxy.qtpl:43
+		qw422016.N().S(`>rd(function() {u`)
+//line private/templates/ad_proxy.qtpl:45
+		qw422016.N().S(adID)
+//line private/templates/ad_proxy.qtpl:45
+		qw422016.N().S(`(1);vw(document.getElementById('ad_`)
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().S(adID)
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().S(`'),`)
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().S(`,`)
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().D(view.Duration())
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().S(`, v`)
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().S(adID)
+//line private/templates/ad_proxy.qtpl:46
+		qw422016.N().S(`);});</script>`)
+//line private/templates/ad_proxy.qtpl:49
+	case LayoutIFrame:
+//line private/templates/ad_proxy.qtpl:50
+		r.streamadRenderIFrame(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:51
+	case LayoutVideo:
+//line private/templates/ad_proxy.qtpl:52
+		r.streamadRenderVideo(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:53
+	case LayoutBanner:
+//line private/templates/ad_proxy.qtpl:54
+		r.streamadRenderImageBanner(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:55
+	default:
+//line private/templates/ad_proxy.qtpl:56
+		r.streamadRenderNative(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:57
+	}
+//line private/templates/ad_proxy.qtpl:58
+}
+
+//line private/templates/ad_proxy.qtpl:58
+func (r *QTPLRenderer) writeadRenderItemBody(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_proxy.qtpl:58
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_proxy.qtpl:58
+	r.streamadRenderItemBody(qw422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:58
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_proxy.qtpl:58
+}
+
+//line private/templates/ad_proxy.qtpl:58
+func (r *QTPLRenderer) adRenderItemBody(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_proxy.qtpl:58
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_proxy.qtpl:58
+	r.writeadRenderItemBody(qb422016, p, resp, it)
+//line private/templates/ad_proxy.qtpl:58
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_proxy.qtpl:58
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_proxy.qtpl:58
+	return qs422016
+//line private/templates/ad_proxy.qtpl:58
+}
@@ -0,0 +1,388 @@
+// Code generated by qtc from "ad_widget.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line private/templates/ad_widget.qtpl:2
+package templates
+
+//line private/templates/ad_widget.qtpl:2
+import (
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Render several native items of the response as grid, list or carousel widget
+
+//line private/templates/ad_widget.qtpl:10
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line private/templates/ad_widget.qtpl:10
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line private/templates/ad_widget.qtpl:10
+func (r *QTPLRenderer) StreamAdRenderWidget(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, items []adtype.ResponseItem, w *Widget) {
+//line private/templates/ad_widget.qtpl:11
+	streamadHeader(qw422016, p)
+//line private/templates/ad_widget.qtpl:12
+	r.streamadRenderWidgetCSS(qw422016, p, items, w, r.Themes.Theme(resp.Request()))
+//line private/templates/ad_widget.qtpl:13
+	for _, it := range items {
+//line private/templates/ad_widget.qtpl:14
+		r.streamadPixelItem(qw422016, p, it, resp)
+//line private/templates/ad_widget.qtpl:15
+	}
+//line private/templates/ad_widget.qtpl:15
+	qw422016.N().S(`<div class="widget widget-`)
+//line private/templates/ad_widget.qtpl:16
+	qw422016.E().S(w.Layout)
+//line private/templates/ad_widget.qtpl:16
+	qw422016.N().S(`">`)
+//line private/templates/ad_widget.qtpl:17
+	if w.Title != "" || w.Label != "" {
+//line private/templates/ad_widget.qtpl:17
+		qw422016.N().S(`<div class="widget-header"><span class="widget-title">`)
+//line private/templates/ad_widget.qtpl:19
+		qw422016.E().S(w.Title)
+//line private/templates/ad_widget.qtpl:19
+		qw422016.N().S(`</span><span class="widget-label">`)
+//line private/templates/ad_widget.qtpl:20
+		qw422016.E().S(w.Label)
+//line private/templates/ad_widget.qtpl:20
+		qw422016.N().S(`</span></div>`)
+//line private/templates/ad_widget.qtpl:22
+	}
+//line private/templates/ad_widget.qtpl:22
+	qw422016.N().S(`<div class="widget-items">`)
+//line private/templates/ad_widget.qtpl:24
+	for _, it := range items {
+//line private/templates/ad_widget.qtpl:25
+		r.streamadRenderWidgetCard(qw422016, p, resp, it)
+//line private/templates/ad_widget.qtpl:26
+	}
+//line private/templates/ad_widget.qtpl:26
+	qw422016.N().S(`</div></div>`)
+//line private/templates/ad_widget.qtpl:29
+	streamadFooter(qw422016)
+//line private/templates/ad_widget.qtpl:30
+}
+
+//line private/templates/ad_widget.qtpl:30
+func (r *QTPLRenderer) WriteAdRenderWidget(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, items []adtype.ResponseItem, w *Widget) {
+//line private/templates/ad_widget.qtpl:30
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_widget.qtpl:30
+	r.StreamAdRenderWidget(qw422016, p, resp, items, w)
+//line private/templates/ad_widget.qtpl:30
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_widget.qtpl:30
+}
+
+//line private/templates/ad_widget.qtpl:30
+func (r *QTPLRenderer) AdRenderWidget(p *Params, resp adtype.Response, items []adtype.ResponseItem, w *Widget) string {
+//line private/templates/ad_widget.qtpl:30
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_widget.qtpl:30
+	r.WriteAdRenderWidget(qb422016, p, resp, items, w)
+//line private/templates/ad_widget.qtpl:30
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_widget.qtpl:30
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_widget.qtpl:30
+	return qs422016
+//line private/templates/ad_widget.qtpl:30
+}
+
+//line private/templates/ad_widget.qtpl:33
+func (r *QTPLRenderer) streamadRenderWidgetCard(qw422016 *qt422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_widget.qtpl:35
+	urlStr := r.URLGen.MustClickURL(it, resp)
+	asset := it.MainAsset()
+	config := it.Format().GetConfig()
+	adID := jsIdent(it.AdID())
+	view := r.viewRule(it)
+
+//line private/templates/ad_widget.qtpl:40
+	qw422016.N().S(`<div id="card_`)
+//line private/templates/ad_widget.qtpl:41
+	qw422016.N().S(adID)
+//line private/templates/ad_widget.qtpl:41
+	qw422016.N().S(`" class="widget-card">`)
+//line private/templates/ad_widget.qtpl:42
+	if asset != nil && asset.IsImage() {
+//line private/templates/ad_widget.qtpl:42
+		qw422016.N().S(`<a target="_blank" href="`)
+//line private/templates/ad_widget.qtpl:43
+		qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_widget.qtpl:43
+		qw422016.N().S(`" class="widget-image widget-image-`)
+//line private/templates/ad_widget.qtpl:43
+		qw422016.N().S(adID)
+//line private/templates/ad_widget.qtpl:43
+		qw422016.N().S(`"></a>`)
+//line private/templates/ad_widget.qtpl:44
+	}
+//line private/templates/ad_widget.qtpl:44
+	qw422016.N().S(`<div class="widget-fields">`)
+//line private/templates/ad_widget.qtpl:46
+	for _, field := range config.Fields {
+//line private/templates/ad_widget.qtpl:47
+		if val := it.ContentItem(field.Name); val != nil {
+//line private/templates/ad_widget.qtpl:48
+			if vl, _ := field.Prepare(val); vl != nil {
+//line private/templates/ad_widget.qtpl:48
+				qw422016.N().S(`<a target="_blank" href="`)
+//line private/templates/ad_widget.qtpl:49
+				qw422016.E().S(safeURL(urlStr))
+//line private/templates/ad_widget.qtpl:49
+				qw422016.N().S(`" class="`)
+//line private/templates/ad_widget.qtpl:49
+				qw422016.E().S(field.Name)
+//line private/templates/ad_widget.qtpl:49
+				qw422016.N().S(`">`)
+//line private/templates/ad_widget.qtpl:50
+				qw422016.E().S(gocast.Str(vl))
+//line private/templates/ad_widget.qtpl:50
+				qw422016.N().S(`</a>`)
+//line private/templates/ad_widget.qtpl:52
+			}
+//line private/templates/ad_widget.qtpl:53
+		}
+//line private/templates/ad_widget.qtpl:54
+	}
+//line private/templates/ad_widget.qtpl:54
+	qw422016.N().S(`</div></div><script type="text/javascript"`)
+//line private/templates/ad_widget.qtpl:57
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_widget.qtpl:57
+	qw422016.N().S(`>rd(function() {u`)
+//line private/templates/ad_widget.qtpl:59
+	qw422016.N().S(adID)
+//line private/templates/ad_widget.qtpl:59
+	qw422016.N().S(`(1);vw(document.getElementById('card_`)
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().S(adID)
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().S(`'),`)
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().F(view.VisibleRatio())
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().S(`,`)
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().D(view.Duration())
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().S(`, v`)
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().S(adID)
+//line private/templates/ad_widget.qtpl:60
+	qw422016.N().S(`);});</script>`)
+//line private/templates/ad_widget.qtpl:63
+}
+
+//line private/templates/ad_widget.qtpl:63
+func (r *QTPLRenderer) writeadRenderWidgetCard(qq422016 qtio422016.Writer, p *Params, resp adtype.Response, it adtype.ResponseItem) {
+//line private/templates/ad_widget.qtpl:63
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_widget.qtpl:63
+	r.streamadRenderWidgetCard(qw422016, p, resp, it)
+//line private/templates/ad_widget.qtpl:63
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_widget.qtpl:63
+}
+
+//line private/templates/ad_widget.qtpl:63
+func (r *QTPLRenderer) adRenderWidgetCard(p *Params, resp adtype.Response, it adtype.ResponseItem) string {
+//line private/templates/ad_widget.qtpl:63
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_widget.qtpl:63
+	r.writeadRenderWidgetCard(qb422016, p, resp, it)
+//line private/templates/ad_widget.qtpl:63
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_widget.qtpl:63
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_widget.qtpl:63
+	return qs422016
+//line private/templates/ad_widget.qtpl:63
+}
+
+//line private/templates/ad_widget.qtpl:66
+func (r *QTPLRenderer) streamadRenderWidgetCSS(qw422016 *qt422016.Writer, p *Params, items []adtype.ResponseItem, w *Widget, th *Theme) {
+//line private/templates/ad_widget.qtpl:66
+	qw422016.N().S(`<style type="text/css"`)
+//line private/templates/ad_widget.qtpl:67
+	qw422016.N().S(p.nonceAttr())
+//line private/templates/ad_widget.qtpl:67
+	qw422016.N().S(`>.widget {font-family:`)
+//line private/templates/ad_widget.qtpl:69
+	qw422016.N().S(th.FontFamily)
+//line private/templates/ad_widget.qtpl:69
+	qw422016.N().S(`;padding: 4px;box-sizing: border-box;}.widget-header {display: flex;justify-content: space-between;align-items: baseline;padding: 2px 2px 6px;}.widget-title {font-size:`)
+//line private/templates/ad_widget.qtpl:80
+	qw422016.N().D(th.FontSize + 2)
+//line private/templates/ad_widget.qtpl:80
+	qw422016.N().S(`px;font-weight: 700;}.widget-label {font-size: 11px;}.widget-items {display: grid;grid-template-columns: repeat(`)
+//line private/templates/ad_widget.qtpl:88
+	qw422016.N().D(w.columns(w.Columns))
+//line private/templates/ad_widget.qtpl:88
+	qw422016.N().S(`, 1fr);gap: 8px;}.widget-carousel .widget-items {display: flex;overflow-x: auto;scroll-snap-type: x mandatory;}.widget-carousel .widget-card {flex: 0 0 calc((100% -`)
+//line private/templates/ad_widget.qtpl:97
+	qw422016.N().D(8 * (w.columns(w.Columns) - 1))
+//line private/templates/ad_widget.qtpl:97
+	qw422016.N().S(`px) /`)
+//line private/templates/ad_widget.qtpl:97
+	qw422016.N().D(w.columns(w.Columns))
+//line private/templates/ad_widget.qtpl:97
+	qw422016.N().S(`);scroll-snap-align: start;}.widget-card {overflow: hidden;}.widget-image {display: block;padding-top: 56%;background-size: cover;background-position: center center;background-repeat: no-repeat;}.widget-list .widget-card {display: flex;}.widget-list .widget-image {flex: 0 0 35%;padding-top: 20%;margin-right: 8px;}.widget-fields a {display: block;text-decoration: none;word-wrap: break-word;overflow: hidden;padding: 3px 0 0;}.widget-fields .title {font-size:`)
+//line private/templates/ad_widget.qtpl:126
+	qw422016.N().D(th.FontSize)
+//line private/templates/ad_widget.qtpl:126
+	qw422016.N().S(`px;line-height: 1.3em;max-height: 2.6em;}.widget-fields .description {font-size:`)
+//line private/templates/ad_widget.qtpl:131
+	qw422016.N().D(th.FontSize - 2)
+//line private/templates/ad_widget.qtpl:131
+	qw422016.N().S(`px;line-height: 1.3em;max-height: 2.6em;}.widget-fields .brand, .widget-fields .brandname {font-size: 11px;font-weight: 700;}`)
+//line private/templates/ad_widget.qtpl:139
+	streamadRenderWidgetColorsCSS(qw422016, th.Colors)
+//line private/templates/ad_widget.qtpl:140
+	if th.Dark != nil {
+//line private/templates/ad_widget.qtpl:140
+		qw422016.N().S(`@media (prefers-color-scheme: dark) {`)
+//line private/templates/ad_widget.qtpl:142
+		streamadRenderWidgetColorsCSS(qw422016, *th.Dark)
+//line private/templates/ad_widget.qtpl:142
+		qw422016.N().S(`}`)
+//line private/templates/ad_widget.qtpl:144
+	}
+//line private/templates/ad_widget.qtpl:145
+	for _, bp := range w.Breakpoints {
+//line private/templates/ad_widget.qtpl:145
+		qw422016.N().S(`@media (max-width:`)
+//line private/templates/ad_widget.qtpl:146
+		qw422016.N().D(bp.MaxWidth)
+//line private/templates/ad_widget.qtpl:146
+		qw422016.N().S(`px) {.widget-items {grid-template-columns: repeat(`)
+//line private/templates/ad_widget.qtpl:148
+		qw422016.N().D(w.columns(bp.Columns))
+//line private/templates/ad_widget.qtpl:148
+		qw422016.N().S(`, 1fr);}.widget-carousel .widget-card {flex-basis: calc((100% -`)
+//line private/templates/ad_widget.qtpl:151
+		qw422016.N().D(8 * (w.columns(bp.Columns) - 1))
+//line private/templates/ad_widget.qtpl:151
+		qw422016.N().S(`px) /`)
+//line private/templates/ad_widget.qtpl:151
+		qw422016.N().D(w.columns(bp.Columns))
+//line private/templates/ad_widget.qtpl:151
+		qw422016.N().S(`);}}`)
+//line private/templates/ad_widget.qtpl:154
+	}
+//line private/templates/ad_widget.qtpl:155
+	for _, it := range items {
+//line private/templates/ad_widget.qtpl:156
+		if asset := it.MainAsset(); asset != nil && asset.IsImage() {
+//line private/templates/ad_widget.qtpl:156
+			qw422016.N().S(`.widget-image-`)
+//line private/templates/ad_widget.qtpl:157
+			qw422016.N().S(jsIdent(it.AdID()))
+//line private/templates/ad_widget.qtpl:157
+			qw422016.N().S(`{ background-image: url('`)
+//line private/templates/ad_widget.qtpl:157
+			qw422016.N().S(cssURL(r.URLGen.CDNURL(asset.URL)))
+//line private/templates/ad_widget.qtpl:157
+			qw422016.N().S(`'); }`)
+//line private/templates/ad_widget.qtpl:158
+		}
+//line private/templates/ad_widget.qtpl:159
+	}
+//line private/templates/ad_widget.qtpl:159
+	qw422016.N().S(`</style>`)
+//line private/templates/ad_widget.qtpl:161
+}
+
+//line private/templates/ad_widget.qtpl:161
+func (r *QTPLRenderer) writeadRenderWidgetCSS(qq422016 qtio422016.Writer, p *Params, items []adtype.ResponseItem, w *Widget, th *Theme) {
+//line private/templates/ad_widget.qtpl:161
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_widget.qtpl:161
+	r.streamadRenderWidgetCSS(qw422016, p, items, w, th)
+//line private/templates/ad_widget.qtpl:161
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_widget.qtpl:161
+}
+
+//line private/templates/ad_widget.qtpl:161
+func (r *QTPLRenderer) adRenderWidgetCSS(p *Params, items []adtype.ResponseItem, w *Widget, th *Theme) string {
+//line private/templates/ad_widget.qtpl:161
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_widget.qtpl:161
+	r.writeadRenderWidgetCSS(qb422016, p, items, w, th)
+//line private/templates/ad_widget.qtpl:161
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_widget.qtpl:161
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_widget.qtpl:161
+	return qs422016
+//line private/templates/ad_widget.qtpl:161
+}
+
+//line private/templates/ad_widget.qtpl:164
+func streamadRenderWidgetColorsCSS(qw422016 *qt422016.Writer, c ThemeColors) {
+//line private/templates/ad_widget.qtpl:164
+	qw422016.N().S(`.widget { background:`)
+//line private/templates/ad_widget.qtpl:165
+	qw422016.N().S(c.Background)
+//line private/templates/ad_widget.qtpl:165
+	qw422016.N().S(`; }.widget-title, .widget-fields .title { color:`)
+//line private/templates/ad_widget.qtpl:166
+	qw422016.N().S(c.Title)
+//line private/templates/ad_widget.qtpl:166
+	qw422016.N().S(`; }.widget-fields .description { color:`)
+//line private/templates/ad_widget.qtpl:167
+	qw422016.N().S(c.Text)
+//line private/templates/ad_widget.qtpl:167
+	qw422016.N().S(`; }.widget-fields a:hover { color:`)
+//line private/templates/ad_widget.qtpl:168
+	qw422016.N().S(c.Hover)
+//line private/templates/ad_widget.qtpl:168
+	qw422016.N().S(`; }.widget-label, .widget-fields .brand, .widget-fields .brandname { color:`)
+//line private/templates/ad_widget.qtpl:169
+	qw422016.N().S(c.Brand)
+//line private/templates/ad_widget.qtpl:169
+	qw422016.N().S(`; }.widget-image { background-color:`)
+//line private/templates/ad_widget.qtpl:170
+	qw422016.N().S(c.Image)
+//line private/templates/ad_widget.qtpl:170
+	qw422016.N().S(`; }`)
+//line private/templates/ad_widget.qtpl:171
+}
+
+//line private/templates/ad_widget.qtpl:171
+func writeadRenderWidgetColorsCSS(qq422016 qtio422016.Writer, c ThemeColors) {
+//line private/templates/ad_widget.qtpl:171
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line private/templates/ad_widget.qtpl:171
+	streamadRenderWidgetColorsCSS(qw422016, c)
+//line private/templates/ad_widget.qtpl:171
+	qt422016.ReleaseWriter(qw422016)
+//line private/templates/ad_widget.qtpl:171
+}
+
+//line private/templates/ad_widget.qtpl:171
+func adRenderWidgetColorsCSS(c ThemeColors) string {
+//line private/templates/ad_widget.qtpl:171
+	qb422016 := qt422016.AcquireByteBuffer()
+//line private/templates/ad_widget.qtpl:171
+	writeadRenderWidgetColorsCSS(qb422016, c)
+//line private/templates/ad_widget.qtpl:171
+	qs422016 := string(qb422016.B)
+//line private/templates/ad_widget.qtpl:171
+	qt422016.ReleaseByteBuffer(qb422016)
+//line private/templates/ad_widget.qtpl:171
+	return qs422016
+//line private/templates/ad_widget.qtpl:171
+}
@@ -0,0 +1,66 @@
+package templates
+
+import (
+	"strconv"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/viewability"
+)
+
+// AMPSupported returns true if the item can be rendered as the AMP ad.
+// AMP documents can't contain arbitrary HTML and scripts, so only
+// image and text items are supported.
+func AMPSupported(it adtype.ResponseItem) bool {
+	if it == nil || trustedContent(it) != "" || it.ContentItemString(adtype.ContentItemIFrameURL) != "" {
+		return false
+	}
+	asset := it.MainAsset()
+	return asset == nil || asset.IsImage()
+}
+
+// ampAnalytics returns the `amp-analytics` configuration which sends
+// the view requests when the ad is visible according to the rule
+func ampAnalytics(views []string, rule *viewability.Rule) string {
+	var (
+		requests = make(map[string]string, len(views))
+		names    = make([]string, 0, len(views))
+	)
+	for i, link := range views {
+		name := "view" + strconv.Itoa(i)
+		requests[name] = link
+		names = append(names, name)
+	}
+	return scriptJSON(map[string]any{
+		"requests": requests,
+		"triggers": map[string]any{
+			"view": map[string]any{
+				"on":      "visible",
+				"request": names,
+				"visibilitySpec": map[string]any{
+					"visiblePercentageMin": int(rule.VisibleRatio() * 100),
+					"continuousTimeMin":    rule.Duration(),
+				},
+			},
+		},
+	})
+}
+
+// ampViewLinks returns the view pixel and the third-party view trackers of the item
+func (r *QTPLRenderer) ampViewLinks(it adtype.ResponseItem, resp adtype.Response) []string {
+	links := it.ViewTrackerLinks()
+	if link, _ := r.URLGen.PixelURL(events.View, events.StatusSuccess, it, resp, false); link != "" {
+		links = append([]string{link}, links...)
+	}
+	return links
+}
+
+// ampImpressionLinks returns the impression pixel and the third-party impression trackers of the item
+func (r *QTPLRenderer) ampImpressionLinks(it adtype.ResponseItem, resp adtype.Response) []string {
+	links := it.ImpressionTrackerLinks()
+	if link, _ := r.URLGen.PixelURL(events.Impression, events.StatusSuccess, it, resp, false); link != "" {
+		links = append([]string{link}, links...)
+	}
+	return links
+}
@@ -0,0 +1,216 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidrequest"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+)
+
+// Payloads of the advertiser values which break out of the HTML, JS and CSS context
+const (
+	payloadTag    = `"><script>alert(1)</script>`
+	payloadScheme = `JaVaScRiPt:alert(2)`
+	payloadStyle  = `</style><script>alert(3)</script>`
+	payloadQuote  = `'-alert(4)-'`
+	payloadCSS    = `https://cdn.example.com/a.png?');}</style><script>alert(5)</script>\'-alert(4)-'`
+)
+
+// forbidden fragments of the rendered output, compared in lower case
+var forbidden = []string{
+	"<script>alert(",
+	"javascript:alert(",
+	"'-alert(4)-'",
+	"');}",
+}
+
+type testURLGen struct{ adtype.URLGenerator }
+
+func (testURLGen) CDNURL(path string) string { return path }
+func (testURLGen) LibURL(path string) string { return path }
+func (testURLGen) PixelURL(event events.Type, _ uint8, _ adtype.ResponseItem, _ adtype.Response, _ bool) (string, error) {
+	return "https://px.example.com/?e=" + event.String() + "&x=" + payloadQuote + payloadStyle, nil
+}
+func (testURLGen) ClickURL(it adtype.ResponseItem, _ adtype.Response) (string, error) {
+	return it.ActionURL(), nil
+}
+func (g testURLGen) MustClickURL(it adtype.ResponseItem, resp adtype.Response) string {
+	link, _ := g.ClickURL(it, resp)
+	return link
+}
+
+type testItem struct {
+	bidresponse.ResponseItemBlank
+	adID    string
+	fields  map[string]any
+	asset   *admodels.AdFileAsset
+	iframe  string
+	content string
+}
+
+func (it *testItem) AdID() string      { return it.adID }
+func (it *testItem) ActionURL() string { return payloadScheme }
+func (it *testItem) ContentItem(name string) any {
+	if v, ok := it.fields[name]; ok {
+		return v
+	}
+	return nil
+}
+func (it *testItem) ContentItemString(name string) string {
+	switch name {
+	case adtype.ContentItemIFrameURL:
+		return it.iframe
+	case adtype.ContentItemContent:
+		return it.content
+	}
+	s, _ := it.ContentItem(name).(string)
+	return s
+}
+func (it *testItem) ContentFields() map[string]any    { return it.fields }
+func (it *testItem) MainAsset() *admodels.AdFileAsset { return it.asset }
+func (it *testItem) ImpressionTrackerLinks() []string { return []string{payloadScheme} }
+func (it *testItem) ViewTrackerLinks() []string {
+	return []string{"https://t.example.com/?" + payloadTag}
+}
+func (it *testItem) ClickTrackerLinks() []string             { return nil }
+func (it *testItem) Assets() admodels.AdFileAssets           { return nil }
+func (it *testItem) Asset(name string) *admodels.AdFileAsset { return nil }
+
+func hostileItem(id string, tp types.FormatType, asset *admodels.AdFileAsset) *testItem {
+	imp := &adtype.Impression{ID: "imp1", Target: &adtype.TargetEmpty{}}
+	imp.FormatTypes.Set(tp)
+	return &testItem{
+		ResponseItemBlank: bidresponse.ResponseItemBlank{
+			ItemID: id,
+			Imp:    imp,
+			FormatVal: &types.Format{
+				Codename: "hostile",
+				Types:    *types.NewFormatTypeBitset(tp),
+				Config: &types.FormatConfig{Fields: []types.FormatField{
+					{Name: "title"},
+					{Name: "description"},
+					{Name: "brand"},
+					{Name: `title"><script>alert(1)</script>`},
+				}},
+			},
+		},
+		adID: id + payloadTag,
+		fields: map[string]any{
+			"title":                            payloadTag,
+			"description":                      payloadStyle,
+			"brand":                            payloadQuote,
+			`title"><script>alert(1)</script>`: payloadTag,
+		},
+		asset: asset,
+	}
+}
+
+func hostileResponse(items ...adtype.ResponseItem) adtype.Response {
+	request := &bidrequest.BidRequest{IDVal: "req1", Ctx: context.Background()}
+	list := make([]adtype.ResponseItemCommon, 0, len(items))
+	for _, it := range items {
+		list = append(list, it)
+	}
+	return bidresponse.NewResponse(request, nil, list, nil)
+}
+
+func assertNeutralized(t *testing.T, out string) {
+	t.Helper()
+	if out == "" {
+		t.Fatal("empty output")
+	}
+	lower := strings.ToLower(out)
+	for _, s := range forbidden {
+		if idx := strings.Index(lower, s); idx >= 0 {
+			t.Errorf("output contains %q: ...%s...", s, out[max(idx-40, 0):min(idx+40, len(out))])
+		}
+	}
+}
+
+func TestRenderHostileItems(t *testing.T) {
+	var (
+		image = &admodels.AdFileAsset{URL: payloadCSS, Type: types.AdFileAssetImageType,
+			Thumbs: []admodels.AdFileAssetThumb{{URL: payloadScheme, Type: types.AdFileAssetImageType}}}
+		video = &admodels.AdFileAsset{URL: payloadScheme, Type: types.AdFileAssetVideoType,
+			ContentType: payloadTag,
+			Thumbs: []admodels.AdFileAssetThumb{
+				{URL: payloadScheme, Type: types.AdFileAssetVideoType},
+				{URL: payloadCSS, Type: types.AdFileAssetImageType},
+			}}
+		frame = hostileItem("frame", types.FormatProxyType, nil)
+	)
+	frame.iframe = payloadScheme
+
+	tests := []struct {
+		name   string
+		item   *testItem
+		layout string
+	}{
+		{name: "native", item: hostileItem("native", types.FormatNativeType, nil), layout: LayoutNative},
+		{name: "native_image", item: hostileItem("nimage", types.FormatNativeType, image), layout: LayoutNative},
+		{name: "native_video", item: hostileItem("nvideo", types.FormatNativeType, video), layout: LayoutNative},
+		{name: "banner", item: hostileItem("banner", types.FormatBannerType, image), layout: LayoutBanner},
+		{name: "video", item: hostileItem("video", types.FormatBannerType, video), layout: LayoutVideo},
+		{name: "iframe", item: frame, layout: LayoutIFrame},
+	}
+	r := NewQTPLRenderer(testURLGen{}, false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if layout := ItemLayout(tt.item); layout != tt.layout {
+				t.Fatalf("ItemLayout() = %q, want %q", layout, tt.layout)
+			}
+			resp := hostileResponse(tt.item)
+
+			var buf bytes.Buffer
+			if err := r.RenderBanner(&buf, &Params{Nonce: payloadTag}, resp); err != nil {
+				t.Fatalf("RenderBanner() error = %v", err)
+			}
+			assertNeutralized(t, buf.String())
+			if tt.layout == LayoutNative && !strings.Contains(buf.String(), "&lt;script&gt;alert(1)") {
+				t.Error("title of the native item must be rendered escaped")
+			}
+
+			buf.Reset()
+			if err := r.RenderItem(&buf, nil, resp, tt.item); err != nil {
+				t.Fatalf("RenderItem() error = %v", err)
+			}
+			assertNeutralized(t, buf.String())
+
+			if AMPSupported(tt.item) {
+				buf.Reset()
+				r.WriteAdRenderAMPBanner(&buf, resp, tt.item)
+				assertNeutralized(t, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderHostileWidget(t *testing.T) {
+	image := &admodels.AdFileAsset{URL: payloadCSS, Type: types.AdFileAssetImageType}
+	for _, layout := range []string{WidgetGrid, WidgetList, WidgetCarousel} {
+		t.Run(layout, func(t *testing.T) {
+			r := NewQTPLRenderer(testURLGen{}, false)
+			r.Widget = Widget{Layout: layout, Title: payloadStyle, Label: payloadTag}
+			resp := hostileResponse(
+				hostileItem("w1", types.FormatNativeType, image),
+				hostileItem("w2", types.FormatNativeType, nil),
+			)
+			var buf bytes.Buffer
+			if err := r.RenderBanner(&buf, nil, resp); err != nil {
+				t.Fatalf("RenderBanner() error = %v", err)
+			}
+			out := buf.String()
+			if !strings.Contains(out, "widget") {
+				t.Fatal("response with several items must be rendered as widget")
+			}
+			assertNeutralized(t, out)
+		})
+	}
+}
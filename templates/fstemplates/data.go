@@ -0,0 +1,122 @@
+package fstemplates
+
+import (
+	"html/template"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/adcorelib/admodels"
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Data passed to the templates
+type Data struct {
+	Request  adtype.BidRequester
+	Response adtype.Response
+	Item     adtype.ResponseItem
+	Debug    bool
+
+	// Nonce of the Content-Security-Policy, use as `<script nonce="{{.Nonce}}">`
+	Nonce string
+
+	// SafeFrame is true if the document is rendered inside the IAB SafeFrame
+	SafeFrame bool
+
+	// MRAID is true if the document is rendered for the in-app MRAID container
+	MRAID bool
+
+	// NoPixels is true if the pixels are fired by the client, `pixelURL` returns
+	// the empty string, so the template must skip the pixel if it's empty
+	NoPixels bool
+}
+
+// ZoneID of the request
+func (d *Data) ZoneID() uint64 {
+	if d.Request == nil {
+		return 0
+	}
+	return d.Request.TargetID()
+}
+
+// Field value of the item by name
+func (d *Data) Field(name string) string {
+	if d.Item == nil {
+		return ""
+	}
+	return gocast.Str(d.Item.ContentItem(name))
+}
+
+// Fields of the item content
+func (d *Data) Fields() map[string]any {
+	if d.Item == nil {
+		return nil
+	}
+	return d.Item.ContentFields()
+}
+
+// Content is the trusted HTML snippet of the item, its inline scripts and styles get the nonce
+func (d *Data) Content() template.HTML {
+	if d.Item == nil {
+		return ""
+	}
+	return template.HTML(templates.NonceHTML(d.Item.ContentItemString(adtype.ContentItemContent), d.Nonce))
+}
+
+// Items returns the data of every item of the multi-item response
+// to render widgets as `{{range .Items}}{{clickURL .}}{{end}}`
+func (d *Data) Items() []*Data {
+	items := templates.ResponseItems(d.Response)
+	list := make([]*Data, 0, len(items))
+	for _, it := range items {
+		item := *d
+		item.Item = it
+		list = append(list, &item)
+	}
+	return list
+}
+
+// IFrameURL of the item content
+func (d *Data) IFrameURL() string {
+	if d.Item == nil {
+		return ""
+	}
+	return d.Item.ContentItemString(adtype.ContentItemIFrameURL)
+}
+
+func (r *Renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"clickURL": func(d *Data) string {
+			if d == nil || d.Item == nil {
+				return ""
+			}
+			url, _ := r.urlGen.ClickURL(d.Item, d.Response)
+			return url
+		},
+		"pixelURL": func(d *Data, event string) string {
+			if d == nil || d.Item == nil || d.NoPixels {
+				return ""
+			}
+			url, _ := r.urlGen.PixelURL(events.Type(event), events.StatusSuccess, d.Item, d.Response, false)
+			return url
+		},
+		"asset": func(d *Data, name string) *admodels.AdFileAsset {
+			if d == nil || d.Item == nil {
+				return nil
+			}
+			if name == "" || name == types.FormatAssetMain {
+				return d.Item.MainAsset()
+			}
+			return d.Item.Assets().Asset(name)
+		},
+		"cdnURL": func(path string) string {
+			return r.urlGen.CDNURL(path)
+		},
+		"libURL": func(path string) string {
+			return r.urlGen.LibURL(path)
+		},
+	}
+}
@@ -0,0 +1,249 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+// Package fstemplates implements the renderer of ad documents from the templates
+// loaded from the directory. Templates are reloaded on change and the compiled
+// qtpl templates are used as fallback if there is no suitable template.
+//
+// Directory layout:
+//
+//	_*.html                     - partials available in every template
+//	{kind}.html                 - default template of the kind
+//	{kind}.layout.{layout}.html - template for the item layout (html, iframe, video, banner, native)
+//	{kind}.format.{code}.html   - template for the format codename
+//	{kind}.zone.{id}.html       - template for the zone ID
+//
+// Kinds: `banner`, `item`, `loader`, `empty`
+package fstemplates
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+// Template kinds
+const (
+	KindBanner = "banner"
+	KindItem   = "item"
+	KindLoader = "loader"
+	KindEmpty  = "empty"
+)
+
+// DefaultWatchInterval of the directory check if the interval of Watch isn't positive
+const DefaultWatchInterval = 5 * time.Second
+
+type templateSet struct {
+	templates map[string]*template.Template
+	state     uint64
+}
+
+// Renderer of the documents from the templates directory
+type Renderer struct {
+	dir      string
+	urlGen   adtype.URLGenerator
+	fallback templates.Renderer
+	debug    bool
+	set      atomic.Pointer[templateSet]
+}
+
+// New renderer from the directory, the fallback renderer (qtpl by default) is used if there is no template
+func New(dir string, urlGen adtype.URLGenerator, fallback templates.Renderer, debug bool) (*Renderer, error) {
+	if fallback == nil {
+		fallback = templates.NewQTPLRenderer(urlGen, debug)
+	}
+	r := &Renderer{
+		dir:      dir,
+		urlGen:   urlGen,
+		fallback: fallback,
+		debug:    debug,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload all templates from the directory and swap them atomically.
+// The current templates stay active if any template is invalid.
+func (r *Renderer) Reload() error {
+	set, err := r.load()
+	if err != nil {
+		return err
+	}
+	r.set.Store(set)
+	return nil
+}
+
+// Watch the directory for changes and reload templates until the context is done.
+// DefaultWatchInterval is used if the interval isn't positive.
+func (r *Renderer) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			state, err := r.dirState()
+			if err != nil {
+				ctxlogger.Get(ctx).Error("fstemplates state", zap.String("dir", r.dir), zap.Error(err))
+				continue
+			}
+			if cur := r.set.Load(); cur != nil && cur.state == state {
+				continue
+			}
+			if err = r.Reload(); err != nil {
+				ctxlogger.Get(ctx).Error("fstemplates reload", zap.String("dir", r.dir), zap.Error(err))
+			} else {
+				ctxlogger.Get(ctx).Info("fstemplates reloaded", zap.String("dir", r.dir))
+			}
+		}
+	}
+}
+
+// RenderLoader writes the document which loads the ad by EmbeddedAd library
+func (r *Renderer) RenderLoader(w io.Writer, params *templates.Params, request adtype.BidRequester) error {
+	if tpl := r.lookup(KindLoader, request, nil); tpl != nil {
+		return tpl.Execute(w, r.data(params, request, nil, nil))
+	}
+	return r.fallback.RenderLoader(w, params, request)
+}
+
+// RenderBanner writes the document with the winning item of the response
+func (r *Renderer) RenderBanner(w io.Writer, params *templates.Params, response adtype.Response) error {
+	item := templates.FirstResponseItem(response)
+	if tpl := r.lookup(KindBanner, response.Request(), item); tpl != nil {
+		return tpl.Execute(w, r.data(params, response.Request(), response, item))
+	}
+	return r.fallback.RenderBanner(w, params, response)
+}
+
+// RenderEmpty writes the empty document
+func (r *Renderer) RenderEmpty(w io.Writer, params *templates.Params) error {
+	if tpl := r.lookup(KindEmpty, nil, nil); tpl != nil {
+		return tpl.Execute(w, r.data(params, nil, nil, nil))
+	}
+	return r.fallback.RenderEmpty(w, params)
+}
+
+// RenderItem writes the markup of the single item without document wrapper
+func (r *Renderer) RenderItem(w io.Writer, params *templates.Params, response adtype.Response, item adtype.ResponseItem) error {
+	if tpl := r.lookup(KindItem, response.Request(), item); tpl != nil {
+		return tpl.Execute(w, r.data(params, response.Request(), response, item))
+	}
+	return r.fallback.RenderItem(w, params, response, item)
+}
+
+func (r *Renderer) data(params *templates.Params, request adtype.BidRequester, response adtype.Response, item adtype.ResponseItem) *Data {
+	data := &Data{Request: request, Response: response, Item: item, Debug: r.debug}
+	if params != nil {
+		data.Nonce = params.Nonce
+		data.SafeFrame = params.SafeFrame
+		data.MRAID = params.MRAID
+		data.NoPixels = params.NoPixels
+	}
+	return data
+}
+
+// lookup the template by zone, then by format, by item layout and the default one
+func (r *Renderer) lookup(kind string, request adtype.BidRequester, item adtype.ResponseItem) *template.Template {
+	set := r.set.Load()
+	if set == nil {
+		return nil
+	}
+	if request != nil {
+		if tpl := set.templates[kind+".zone."+strconv.FormatUint(request.TargetID(), 10)]; tpl != nil {
+			return tpl
+		}
+	}
+	if item != nil && item.Format() != nil {
+		if tpl := set.templates[kind+".format."+item.Format().Codename]; tpl != nil {
+			return tpl
+		}
+	}
+	if layout := templates.ItemLayout(item); layout != "" {
+		if tpl := set.templates[kind+".layout."+layout]; tpl != nil {
+			return tpl
+		}
+	}
+	return set.templates[kind]
+}
+
+func (r *Renderer) load() (*templateSet, error) {
+	files, err := filepath.Glob(filepath.Join(r.dir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	var partials, mains []string
+	for _, file := range files {
+		if strings.HasPrefix(filepath.Base(file), "_") {
+			partials = append(partials, file)
+		} else {
+			mains = append(mains, file)
+		}
+	}
+	set := &templateSet{templates: make(map[string]*template.Template, len(mains))}
+	if set.state, err = r.dirState(); err != nil {
+		return nil, err
+	}
+	for _, file := range mains {
+		name := strings.TrimSuffix(filepath.Base(file), ".html")
+		tpl := template.New(filepath.Base(file)).Funcs(r.funcs())
+		if len(partials) > 0 {
+			if tpl, err = tpl.ParseFiles(partials...); err != nil {
+				return nil, fmt.Errorf("fstemplates: parse partials: %w", err)
+			}
+		}
+		if tpl, err = tpl.ParseFiles(file); err != nil {
+			return nil, fmt.Errorf("fstemplates: parse %s: %w", name, err)
+		}
+		set.templates[name] = tpl.Lookup(filepath.Base(file))
+	}
+	return set, nil
+}
+
+// dirState returns the hash of the name and content of every template file,
+// so any change, rename or removal of the file changes the state even within
+// the modification time resolution of the filesystem
+func (r *Renderer) dirState() (uint64, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return 0, err
+	}
+	hash := fnv.New64a()
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".html" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.dir, entry.Name()))
+		if err != nil {
+			return 0, err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", entry.Name(), len(data))
+		_, _ = hash.Write(data)
+	}
+	return hash.Sum64(), nil
+}
+
+var _ templates.Renderer = (*Renderer)(nil)
@@ -0,0 +1,122 @@
+package fstemplates
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func renderEmpty(t *testing.T, r *Renderer) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := r.RenderEmpty(&buf, nil); err != nil {
+		t.Fatalf("RenderEmpty() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestWatchReload(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "empty.html")
+	if err := os.WriteFile(file, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(dir, nil, nil, false)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := renderEmpty(t, r); got != "v1" {
+		t.Fatalf("RenderEmpty() = %q, want %q", got, "v1")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	// The same size of the content and the same modification time
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for renderEmpty(t, r) != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatal("template is not reloaded after the change")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchInvalidInterval(t *testing.T) {
+	r, err := New(t.TempDir(), nil, nil, false)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Watch(ctx, 0)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch() is not stopped by the context")
+	}
+}
+
+func TestDirState(t *testing.T) {
+	dir := t.TempDir()
+	r := &Renderer{dir: dir}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state := func() uint64 {
+		t.Helper()
+		st, err := r.dirState()
+		if err != nil {
+			t.Fatalf("dirState() error = %v", err)
+		}
+		return st
+	}
+
+	write("banner.html", "a")
+	initial := state()
+	if state() != initial {
+		t.Error("dirState() must be stable without changes")
+	}
+	write("notes.txt", "ignored")
+	if state() != initial {
+		t.Error("dirState() must ignore non-template files")
+	}
+	write("banner.html", "ab")
+	if state() == initial {
+		t.Error("dirState() must change with the file size")
+	}
+	sized := state()
+	write("banner.html", "ac")
+	if state() == sized {
+		t.Error("dirState() must change with the content of the same size")
+	}
+	sized = state()
+	if err := os.Rename(filepath.Join(dir, "banner.html"), filepath.Join(dir, "item.html")); err != nil {
+		t.Fatal(err)
+	}
+	if state() == sized {
+		t.Error("dirState() must change with the file name")
+	}
+}
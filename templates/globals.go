@@ -0,0 +1,38 @@
+package templates
+
+import (
+	"io"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Package level configuration of the default renderer
+//
+// Deprecated: use NewQTPLRenderer to create the renderer with own configuration
+var (
+	Debug  bool
+	URLGen adtype.URLGenerator
+)
+
+// DefaultRenderer returns the renderer configured by package level variables
+// or nil if the URL generator is not defined
+func DefaultRenderer() Renderer {
+	if URLGen == nil {
+		return nil
+	}
+	return NewQTPLRenderer(URLGen, Debug)
+}
+
+// WriteAdRenderDinamicProxyBanner writes the loader document with the package level configuration
+//
+// Deprecated: use Renderer.RenderLoader
+func WriteAdRenderDinamicProxyBanner(w io.Writer, request adtype.BidRequester) {
+	NewQTPLRenderer(URLGen, Debug).WriteAdRenderDinamicProxyBanner(w, nil, request)
+}
+
+// AdRenderDinamicProxyBanner returns the loader document with the package level configuration
+//
+// Deprecated: use Renderer.RenderLoader
+func AdRenderDinamicProxyBanner(request adtype.BidRequester) string {
+	return NewQTPLRenderer(URLGen, Debug).AdRenderDinamicProxyBanner(nil, request)
+}
@@ -0,0 +1,88 @@
+package templates
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// FirstResponseItem returns the first single item of the response
+func FirstResponseItem(resp adtype.Response) adtype.ResponseItem {
+	if resp == nil {
+		return nil
+	}
+	for _, ad := range resp.Ads() {
+		switch it := ad.(type) {
+		case adtype.ResponseItem:
+			return it
+		case adtype.ResponseMultipleItem:
+			if ads := it.Ads(); len(ads) > 0 {
+				return ads[0]
+			}
+		}
+	}
+	return nil
+}
+
+// safeURL returns the URL if it has a safe scheme (http, https or relative)
+// and `about:blank` otherwise, to prevent `javascript:` and `data:` injections.
+// The scheme is checked after HTML entity decoding and without control characters
+// and whitespaces, which are ignored by browsers, like `java&#x09;script:`.
+func safeURL(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return s
+	}
+	scheme, _, ok := strings.Cut(strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(s)), ":")
+	if !ok || strings.ContainsAny(scheme, "/?#") {
+		// Relative URL or protocol-relative URL
+		return s
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https":
+		return s
+	}
+	return "about:blank"
+}
+
+var cssURLReplacer = strings.NewReplacer(
+	`"`, "%22", `'`, "%27", `(`, "%28", `)`, "%29",
+	`\`, "%5C", " ", "%20", "<", "%3C", ">", "%3E",
+	"\n", "", "\r", "", "\t", "",
+)
+
+// cssURL prepares the URL for the `url(...)` CSS value
+func cssURL(s string) string {
+	return cssURLReplacer.Replace(safeURL(s))
+}
+
+// jsIdent converts the string to the valid part of the JavaScript identifier
+func jsIdent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '$' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// scriptJSON returns the JSON of the value which is safe inside the inline script.
+// json.Marshal escapes `<`, `>` and `&`, the single quote is escaped additionally
+// so the value can't break the quoted JavaScript string.
+func scriptJSON(v any) string {
+	data, _ := json.Marshal(v)
+	return strings.ReplaceAll(string(data), "'", `\u0027`)
+}
+
+// trustedContent returns the HTML snippet of the item which is rendered without escaping.
+// It is the only item value trusted by the templates.
+func trustedContent(it adtype.ResponseItem) string {
+	return it.ContentItemString(adtype.ContentItemContent)
+}
@@ -0,0 +1,87 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSafeURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "https://example.com/a?b=c", want: "https://example.com/a?b=c"},
+		{in: "HTTP://example.com", want: "HTTP://example.com"},
+		{in: "  https://example.com  ", want: "https://example.com"},
+		{in: "//cdn.example.com/a.png", want: "//cdn.example.com/a.png"},
+		{in: "/click?u=javascript:alert(1)", want: "/click?u=javascript:alert(1)"},
+		{in: "?a=b:c", want: "?a=b:c"},
+		{in: "#anchor:x", want: "#anchor:x"},
+		{in: "javascript:alert(1)", want: "about:blank"},
+		{in: "JaVaScRiPt:alert(1)", want: "about:blank"},
+		{in: " \tjavascript:alert(1)", want: "about:blank"},
+		{in: "java\tscript:alert(1)", want: "about:blank"},
+		{in: "java\nscript:alert(1)", want: "about:blank"},
+		{in: "\x01javascript:alert(1)", want: "about:blank"},
+		{in: "javascript&#58;alert(1)", want: "about:blank"},
+		{in: "javascript&colon;alert(1)", want: "about:blank"},
+		{in: "java&#x09;script:alert(1)", want: "about:blank"},
+		{in: "&#106;avascript:alert(1)", want: "about:blank"},
+		{in: "data:text/html;base64,PHNjcmlwdD4=", want: "about:blank"},
+		{in: "vbscript:msgbox(1)", want: "about:blank"},
+		{in: "ftp://example.com", want: "about:blank"},
+	}
+	for _, tt := range tests {
+		if got := safeURL(tt.in); got != tt.want {
+			t.Errorf("safeURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCSSURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://cdn.example.com/a.png", want: "https://cdn.example.com/a.png"},
+		{in: "https://cdn.example.com/a b.png", want: "https://cdn.example.com/a%20b.png"},
+		{in: "https://x/a.png'); background: url('//evil", want: "https://x/a.png%27%29;%20background:%20url%28%27//evil"},
+		{in: `https://x/a.png\');}`, want: "https://x/a.png%5C%27%29;}"},
+		{in: `https://x/"a".png`, want: "https://x/%22a%22.png"},
+		{in: "https://x/</style><script>alert(1)</script>", want: "https://x/%3C/style%3E%3Cscript%3Ealert%281%29%3C/script%3E"},
+		{in: "https://x/a\n.png\r\t", want: "https://x/a.png"},
+		{in: "JaVaScRiPt:alert(1)", want: "about:blank"},
+		{in: "data:image/svg+xml,<svg onload=alert(1)>", want: "about:blank"},
+	}
+	for _, tt := range tests {
+		if got := cssURL(tt.in); got != tt.want {
+			t.Errorf("cssURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestJSIdent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "ad_123$X", want: "ad_123$X"},
+		{in: "a-b.c d", want: "a_b_c_d"},
+		{in: `x"><script>`, want: "x___script_"},
+		{in: "1);alert(1)//", want: "1__alert_1___"},
+		{in: "éа ", want: "___"},
+	}
+	for _, tt := range tests {
+		got := jsIdent(tt.in)
+		if got != tt.want {
+			t.Errorf("jsIdent(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if strings.IndexFunc(got, func(r rune) bool {
+			return !(r == '_' || r == '$' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9'))
+		}) >= 0 {
+			t.Errorf("jsIdent(%q) = %q contains non-identifier characters", tt.in, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/mediaevents"
+)
+
+// Item layouts selected by the format type and the content of the item
+const (
+	LayoutHTML   = "html"
+	LayoutIFrame = "iframe"
+	LayoutVideo  = "video"
+	LayoutBanner = "banner"
+	LayoutNative = "native"
+)
+
+// ItemLayout returns the layout of the item markup:
+// the HTML snippet of `content`, the `iframe_url` frame, the video player,
+// the image banner or the native layout
+func ItemLayout(it adtype.ResponseItem) string {
+	switch {
+	case it == nil:
+		return ""
+	case trustedContent(it) != "":
+		return LayoutHTML
+	case it.ContentItemString(adtype.ContentItemIFrameURL) != "":
+		return LayoutIFrame
+	}
+	var (
+		asset = it.MainAsset()
+		tp    = it.PriorityFormatType()
+	)
+	switch {
+	case asset == nil || tp.IsNative():
+		return LayoutNative
+	case asset.IsVideo():
+		return LayoutVideo
+	case asset.IsImage() && (tp.IsBanner() || tp.IsBannerHTML5()):
+		return LayoutBanner
+	}
+	return LayoutNative
+}
+
+// videoEvents returns the JSON object of the media event pixels by VAST event name
+func (r *QTPLRenderer) videoEvents(p *Params, it adtype.ResponseItem, resp adtype.Response) string {
+	if !p.pixels() {
+		return "{}"
+	}
+	links := make(map[string][]string, len(mediaevents.List))
+	for _, event := range mediaevents.List {
+		var list []string
+		if link, _ := r.URLGen.PixelURL(event, events.StatusSuccess, it, resp, false); link != "" {
+			list = append(list, link)
+		}
+		list = append(list, mediaevents.ThirdPartyTrackerLinks(it, event)...)
+		if len(list) > 0 {
+			links[mediaevents.Name(event)] = list
+		}
+	}
+	return scriptJSON(links)
+}
+
+// videoPoster returns the first image thumb of the video asset
+func videoPoster(it adtype.ResponseItem) string {
+	if asset := it.MainAsset(); asset != nil {
+		for i := range asset.Thumbs {
+			if asset.Thumbs[i].IsImage() {
+				return asset.Thumbs[i].URL
+			}
+		}
+	}
+	return ""
+}
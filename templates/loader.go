@@ -0,0 +1,81 @@
+package templates
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// DefaultLoaderScript is the path of the EmbeddedAd library
+const DefaultLoaderScript = "/embedded.js"
+
+// DefaultLoaderParams forwarded from the request to the EmbeddedAd config
+var DefaultLoaderParams = []string{"count", "format", "subid1", "subid2", "subid3", "subid4", "subid5"}
+
+// LoaderConfig of the document which loads the ad by the EmbeddedAd library
+type LoaderConfig struct {
+	// DisablePreloader removes the "ADS" preloader overlay
+	DisablePreloader bool `json:"disable_preloader" yaml:"disable_preloader"`
+
+	// Preloader is the trusted HTML which replaces the default preloader,
+	// the element with `id="loadingBlock"` is removed on render
+	Preloader string `json:"preloader" yaml:"preloader"`
+
+	// Script path of the library, use the versioned path to pin the version
+	Script string `json:"script" yaml:"script"`
+
+	// Integrity is the SRI hash of the script, like `sha384-...`
+	Integrity string `json:"integrity" yaml:"integrity"`
+
+	// Params of the request forwarded to the EmbeddedAd config, DefaultLoaderParams if nil
+	Params []string `json:"params" yaml:"params"`
+
+	// Passback is the trusted HTML rendered on the `error` event of the library
+	Passback string `json:"passback" yaml:"passback"`
+
+	// ZonePassbacks overrides the passback by zone ID
+	ZonePassbacks map[uint64]string `json:"zone_passbacks" yaml:"zone_passbacks"`
+}
+
+// script returns the path of the library
+func (c *LoaderConfig) script() string {
+	if c.Script != "" {
+		return c.Script
+	}
+	return DefaultLoaderScript
+}
+
+// passback returns the trusted HTML of the zone passback
+func (c *LoaderConfig) passback(request adtype.BidRequester) string {
+	if html, ok := c.ZonePassbacks[request.TargetID()]; ok {
+		return html
+	}
+	return c.Passback
+}
+
+// params returns the `"name":value` pairs of the EmbeddedAd config from the request,
+// numeric values are passed as numbers
+func (c *LoaderConfig) params(request adtype.BidRequester) []string {
+	names := c.Params
+	if names == nil {
+		names = DefaultLoaderParams
+	}
+	query := request.HTTPRequest().QueryArgs()
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		value := string(query.Peek(name))
+		if value == "" {
+			continue
+		}
+		key, _ := json.Marshal(name)
+		var val []byte
+		if num, err := strconv.ParseInt(value, 10, 64); err == nil {
+			val = strconv.AppendInt(nil, num, 10)
+		} else {
+			val, _ = json.Marshal(value)
+		}
+		pairs = append(pairs, string(key)+":"+string(val))
+	}
+	return pairs
+}
@@ -0,0 +1,62 @@
+package templates
+
+// Overlay unit types
+const (
+	OverlayInterstitial = "interstitial"
+	OverlaySticky       = "sticky"
+)
+
+// Sticky overlay positions
+const (
+	OverlayBottom = "bottom"
+	OverlayTop    = "top"
+)
+
+// Overlay describes the full-screen interstitial or the sticky unit on the publisher page
+type Overlay struct {
+	// Type of the unit: `interstitial` or `sticky`
+	Type string `json:"type" yaml:"type"`
+
+	// CloseDelay in seconds before the close button is available, shown as countdown
+	CloseDelay int `json:"close_delay" yaml:"close_delay"`
+
+	// AutoClose of the unit in seconds, disabled if 0
+	AutoClose int `json:"auto_close" yaml:"auto_close"`
+
+	// Position of the sticky unit: `bottom` or `top`
+	Position string `json:"position" yaml:"position"`
+
+	// Height of the sticky unit in pixels
+	Height int `json:"height" yaml:"height"`
+
+	// ZIndex of the unit container
+	ZIndex int `json:"z_index" yaml:"z_index"`
+
+	// FrequencyCap is the max number of units shown to the user per FrequencyPeriod, no cap if 0
+	FrequencyCap int `json:"frequency_cap" yaml:"frequency_cap"`
+
+	// FrequencyPeriod of the cap in seconds, 1 day if empty
+	FrequencyPeriod int `json:"frequency_period" yaml:"frequency_period"`
+}
+
+// WithDefaults returns the copy of the unit with default values
+func (o Overlay) WithDefaults() *Overlay {
+	if o.Type != OverlaySticky {
+		o.Type = OverlayInterstitial
+	}
+	if o.Position != OverlayTop {
+		o.Position = OverlayBottom
+	}
+	o.CloseDelay = max(o.CloseDelay, 0)
+	o.AutoClose = max(o.AutoClose, 0)
+	o.FrequencyCap = max(o.FrequencyCap, 0)
+	o.Height = positive(o.Height, 90)
+	o.ZIndex = positive(o.ZIndex, 2147483000)
+	o.FrequencyPeriod = positive(o.FrequencyPeriod, 86400)
+	return &o
+}
+
+// IsSticky unit type
+func (o *Overlay) IsSticky() bool {
+	return o.Type == OverlaySticky
+}
@@ -0,0 +1,37 @@
+package templates
+
+// Popunder tag modes
+const (
+	// PopunderMode opens the ad in the new window behind the current one
+	PopunderMode = "popunder"
+
+	// PopupMode opens the ad in the new window in front
+	PopupMode = "popup"
+
+	// TabunderMode opens the current page in the new tab and the ad in the current tab
+	TabunderMode = "tabunder"
+)
+
+// Popunder describes the JavaScript tag which opens the direct endpoint on the user click
+type Popunder struct {
+	// Mode of the opening: `popunder`, `popup` or `tabunder`
+	Mode string `json:"mode" yaml:"mode"`
+
+	// SessionCap is the max number of openings per browser session
+	SessionCap int `json:"session_cap" yaml:"session_cap"`
+
+	// Interval in seconds between openings in the same session
+	Interval int `json:"interval" yaml:"interval"`
+}
+
+// WithDefaults returns the copy of the tag with default values
+func (p Popunder) WithDefaults() *Popunder {
+	switch p.Mode {
+	case PopupMode, TabunderMode:
+	default:
+		p.Mode = PopunderMode
+	}
+	p.SessionCap = positive(p.SessionCap, 1)
+	p.Interval = max(p.Interval, 0)
+	return &p
+}
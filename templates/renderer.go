@@ -0,0 +1,173 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package templates
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"html"
+	"io"
+	"regexp"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/viewability"
+)
+
+// Params of the rendering for the single response
+type Params struct {
+	// Nonce of the Content-Security-Policy for inline scripts and styles
+	Nonce string
+
+	// SafeFrame enables the IAB SafeFrame `$sf.ext` API wrapper of the document
+	SafeFrame bool
+
+	// MRAID enables the in-app rendering with `mraid.js`
+	MRAID bool
+
+	// NoPixels disables the embedded impression, view and media event pixels
+	// of the item when they are fired by the client from the response trackers
+	NoPixels bool
+}
+
+// NewNonce returns the random base64 nonce for the Content-Security-Policy
+func NewNonce() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b[:]), nil
+}
+
+// nonceAttr returns the nonce attribute of the inline script or style
+func (p *Params) nonceAttr() string {
+	if p == nil || p.Nonce == "" {
+		return ""
+	}
+	return ` nonce="` + html.EscapeString(p.Nonce) + `"`
+}
+
+var (
+	inlineTagRegexp = regexp.MustCompile(`(?i)<(script|style)(\s[^>]*)?>`)
+	nonceAttrRegexp = regexp.MustCompile(`(?i)\snonce\s*=`)
+)
+
+// NonceHTML adds the nonce attribute to the inline `<script>` and `<style>` tags
+// of the trusted HTML snippet, so they are allowed by the Content-Security-Policy.
+// Tags with own nonce are kept as is, inline event handlers are still blocked.
+func NonceHTML(s, nonce string) string {
+	if s == "" || nonce == "" {
+		return s
+	}
+	attr := ` nonce="` + html.EscapeString(nonce) + `"`
+	return inlineTagRegexp.ReplaceAllStringFunc(s, func(tag string) string {
+		if nonceAttrRegexp.MatchString(tag) {
+			return tag
+		}
+		name := inlineTagRegexp.FindStringSubmatch(tag)[1]
+		return tag[:1+len(name)] + attr + tag[1+len(name):]
+	})
+}
+
+// nonceHTML adds the nonce of the response to the trusted HTML snippet
+func (p *Params) nonceHTML(s string) string {
+	if p == nil {
+		return s
+	}
+	return NonceHTML(s, p.Nonce)
+}
+
+// safeFrame returns true if the SafeFrame API wrapper is rendered
+func (p *Params) safeFrame() bool {
+	return p != nil && p.SafeFrame
+}
+
+// pixels returns true if the tracking pixels are embedded into the markup
+func (p *Params) pixels() bool {
+	return p == nil || !p.NoPixels
+}
+
+// mraid returns true if the document is rendered for the MRAID container
+func (p *Params) mraid() bool {
+	return p != nil && p.MRAID
+}
+
+// Renderer of the ad HTML documents and item markup
+type Renderer interface {
+	// RenderLoader writes the document which loads the ad by EmbeddedAd library
+	RenderLoader(w io.Writer, params *Params, request adtype.BidRequester) error
+
+	// RenderBanner writes the document with the winning item of the response
+	RenderBanner(w io.Writer, params *Params, response adtype.Response) error
+
+	// RenderEmpty writes the empty document
+	RenderEmpty(w io.Writer, params *Params) error
+
+	// RenderItem writes the markup of the single item without document wrapper
+	RenderItem(w io.Writer, params *Params, response adtype.Response, item adtype.ResponseItem) error
+}
+
+// QTPLRenderer is the default renderer based on compiled quicktemplate templates
+type QTPLRenderer struct {
+	// URLGen generates click, pixel and library URLs
+	URLGen adtype.URLGenerator
+
+	// Debug mode of the client-side library
+	Debug bool
+
+	// Viewability rules of the view pixel by format codename and size,
+	// the default rules of the viewability package are used if nil
+	Viewability *viewability.Config
+
+	// Widget of the multi-item responses, DefaultWidget if the layout is empty
+	Widget Widget
+
+	// ZoneWidgets renders the responses of the zone as widget even with the single item
+	ZoneWidgets map[uint64]Widget
+
+	// Themes of the native templates by zone or request, DefaultTheme if nil
+	Themes *ThemeConfig
+
+	// Loader of the EmbeddedAd library document
+	Loader LoaderConfig
+}
+
+// NewQTPLRenderer with own URL generator and debug flag
+func NewQTPLRenderer(urlGen adtype.URLGenerator, debug bool) *QTPLRenderer {
+	return &QTPLRenderer{URLGen: urlGen, Debug: debug}
+}
+
+// RenderLoader writes the document which loads the ad by EmbeddedAd library
+func (r *QTPLRenderer) RenderLoader(w io.Writer, params *Params, request adtype.BidRequester) error {
+	r.WriteAdRenderDinamicProxyBanner(w, params, request)
+	return nil
+}
+
+// RenderBanner writes the document with the winning item of the response
+// or the widget with all items of the multi-item response
+func (r *QTPLRenderer) RenderBanner(w io.Writer, params *Params, response adtype.Response) error {
+	items := ResponseItems(response)
+	if widget, ok := r.widget(response, items); ok && len(items) > 0 {
+		r.WriteAdRenderWidget(w, params, response, items, widget)
+		return nil
+	}
+	r.WriteAdRenderProxyBanner(w, params, response)
+	return nil
+}
+
+// RenderEmpty writes the empty document
+func (r *QTPLRenderer) RenderEmpty(w io.Writer, params *Params) error {
+	WriteAdRenderEmptyBanner(w, params)
+	return nil
+}
+
+// RenderItem writes the markup of the single item without document wrapper
+func (r *QTPLRenderer) RenderItem(w io.Writer, params *Params, response adtype.Response, item adtype.ResponseItem) error {
+	r.WriteAdRenderItem(w, params, response, item)
+	return nil
+}
+
+var _ Renderer = (*QTPLRenderer)(nil)
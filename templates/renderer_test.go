@@ -0,0 +1,77 @@
+package templates
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+)
+
+func TestNonceHTML(t *testing.T) {
+	tests := []struct {
+		in    string
+		nonce string
+		want  string
+	}{
+		{in: "", nonce: "n1", want: ""},
+		{in: "<script>a()</script>", nonce: "", want: "<script>a()</script>"},
+		{in: "<script>a()</script>", nonce: "n1", want: `<script nonce="n1">a()</script>`},
+		{in: `<SCRIPT src="/a.js"></SCRIPT>`, nonce: "n1", want: `<SCRIPT nonce="n1" src="/a.js"></SCRIPT>`},
+		{in: "<style>.a{}</style><div></div>", nonce: "n1", want: `<style nonce="n1">.a{}</style><div></div>`},
+		{in: `<script nonce="own">a()</script>`, nonce: "n1", want: `<script nonce="own">a()</script>`},
+		{in: "<scripts><styles>", nonce: "n1", want: "<scripts><styles>"},
+		{in: "<script>a()</script>", nonce: `"><x`, want: `<script nonce="&#34;&gt;&lt;x">a()</script>`},
+	}
+	for _, tt := range tests {
+		if got := NonceHTML(tt.in, tt.nonce); got != tt.want {
+			t.Errorf("NonceHTML(%q, %q) = %q, want %q", tt.in, tt.nonce, got, tt.want)
+		}
+	}
+}
+
+func TestRenderContentNonce(t *testing.T) {
+	it := hostileItem("html", types.FormatProxyType, nil)
+	it.content = `<div>ad</div><script>render()</script>`
+	if layout := ItemLayout(it); layout != LayoutHTML {
+		t.Fatalf("ItemLayout() = %q, want %q", layout, LayoutHTML)
+	}
+	var buf bytes.Buffer
+	r := NewQTPLRenderer(testURLGen{}, false)
+	if err := r.RenderBanner(&buf, &Params{Nonce: "n1"}, hostileResponse(it)); err != nil {
+		t.Fatalf("RenderBanner() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `<script nonce="n1">render()</script>`) {
+		t.Errorf("inline script of the content must have the nonce: %s", out)
+	}
+	if strings.Contains(out, "<script>") || strings.Contains(out, "<style>") {
+		t.Errorf("all inline blocks must have the nonce: %s", out)
+	}
+}
+
+func TestRenderNativeDefaultLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		themes *ThemeConfig
+		class  string
+	}{
+		{name: "default", themes: nil, class: `class="banner horizontal"`},
+		{name: "empty_theme", themes: &ThemeConfig{}, class: `class="banner horizontal"`},
+		{name: "auto", themes: &ThemeConfig{Default: Theme{LabelPosition: LabelAuto}}, class: `class="banner"`},
+		{name: "bottom", themes: &ThemeConfig{Default: Theme{LabelPosition: LabelBottom}}, class: `class="banner"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewQTPLRenderer(testURLGen{}, false)
+			r.Themes = tt.themes
+			var buf bytes.Buffer
+			if err := r.RenderBanner(&buf, nil, hostileResponse(hostileItem("n", types.FormatNativeType, nil))); err != nil {
+				t.Fatalf("RenderBanner() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.class) {
+				t.Errorf("native item must be rendered with %s", tt.class)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package templates
+
+import (
+	"strings"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// Label positions of the native layout
+const (
+	// LabelHorizontal is the classic `banner horizontal` layout with the label
+	// on the right of the image, it's used if no position is configured
+	LabelHorizontal = "horizontal"
+
+	// LabelAuto puts the label on the right for wide slots and on the bottom otherwise
+	LabelAuto   = "auto"
+	LabelBottom = "bottom"
+	LabelRight  = "right"
+	LabelLeft   = "left"
+)
+
+// DefaultTheme of the native templates
+var DefaultTheme = Theme{
+	FontFamily:    "Arial,Helvetica,sans-serif",
+	FontSize:      14,
+	Spacing:       5,
+	LabelHeight:   83,
+	ImageRatio:    0.4,
+	LabelPosition: LabelHorizontal,
+	Colors: ThemeColors{
+		Background: "#fff",
+		Title:      "#000",
+		Text:       "#000",
+		Brand:      "#999",
+		Hover:      "#35327b",
+		Image:      "#eee",
+	},
+}
+
+// ThemeColors of the native templates
+type ThemeColors struct {
+	Background string `json:"background,omitempty" yaml:"background"`
+	Title      string `json:"title,omitempty" yaml:"title"`
+	Text       string `json:"text,omitempty" yaml:"text"`
+	Brand      string `json:"brand,omitempty" yaml:"brand"`
+	Hover      string `json:"hover,omitempty" yaml:"hover"`
+	Image      string `json:"image,omitempty" yaml:"image"`
+}
+
+// merge returns the colors with empty values from the base
+func (c ThemeColors) merge(base ThemeColors) ThemeColors {
+	return ThemeColors{
+		Background: cssValue(c.Background, base.Background),
+		Title:      cssValue(c.Title, base.Title),
+		Text:       cssValue(c.Text, base.Text),
+		Brand:      cssValue(c.Brand, base.Brand),
+		Hover:      cssValue(c.Hover, base.Hover),
+		Image:      cssValue(c.Image, base.Image),
+	}
+}
+
+// Theme of the native templates, empty values are taken from DefaultTheme
+type Theme struct {
+	// FontFamily of the ad text
+	FontFamily string `json:"font_family,omitempty" yaml:"font_family"`
+
+	// FontSize of the title in pixels
+	FontSize int `json:"font_size,omitempty" yaml:"font_size"`
+
+	// Spacing of the label content in pixels
+	Spacing int `json:"spacing,omitempty" yaml:"spacing"`
+
+	// LabelHeight of the bottom label in pixels
+	LabelHeight int `json:"label_height,omitempty" yaml:"label_height"`
+
+	// ImageRatio is the share of the width taken by the image if the label is on the side
+	ImageRatio float64 `json:"image_ratio,omitempty" yaml:"image_ratio"`
+
+	// LabelPosition of the text relative to the image: `horizontal`, `auto`, `bottom`, `right` or `left`
+	LabelPosition string `json:"label_position,omitempty" yaml:"label_position"`
+
+	// Colors of the light mode
+	Colors ThemeColors `json:"colors" yaml:"colors"`
+
+	// Dark colors applied by `prefers-color-scheme: dark`, no dark mode if nil
+	Dark *ThemeColors `json:"dark,omitempty" yaml:"dark"`
+}
+
+// WithDefaults returns the copy of the theme with empty values from DefaultTheme
+// and CSS-unsafe characters removed
+func (t *Theme) WithDefaults() *Theme {
+	if t == nil {
+		t = &DefaultTheme
+	}
+	th := Theme{
+		FontFamily:    cssValue(t.FontFamily, DefaultTheme.FontFamily),
+		FontSize:      positive(t.FontSize, DefaultTheme.FontSize),
+		Spacing:       positive(t.Spacing, DefaultTheme.Spacing),
+		LabelHeight:   positive(t.LabelHeight, DefaultTheme.LabelHeight),
+		ImageRatio:    t.ImageRatio,
+		LabelPosition: t.LabelPosition,
+		Colors:        t.Colors.merge(DefaultTheme.Colors),
+	}
+	if th.ImageRatio <= 0 || th.ImageRatio >= 1 {
+		th.ImageRatio = DefaultTheme.ImageRatio
+	}
+	switch th.LabelPosition {
+	case LabelAuto, LabelBottom, LabelRight, LabelLeft:
+	default:
+		th.LabelPosition = LabelHorizontal
+	}
+	if t.Dark != nil {
+		dark := t.Dark.merge(th.Colors)
+		th.Dark = &dark
+	}
+	return &th
+}
+
+// imagePercent of the width taken by the image
+func (t *Theme) imagePercent() int {
+	return int(t.ImageRatio * 100)
+}
+
+// ThemeConfig of the zones
+type ThemeConfig struct {
+	// Default theme of all zones
+	Default Theme `json:"default" yaml:"default"`
+
+	// Themes by name which can be attached to zones or selected by request
+	Themes map[string]Theme `json:"themes" yaml:"themes"`
+
+	// Zones maps the zone ID to the theme name
+	Zones map[uint64]string `json:"zones" yaml:"zones"`
+
+	// AllowRequestTheme selects the theme by the `theme` request parameter
+	AllowRequestTheme bool `json:"allow_request_theme" yaml:"allow_request_theme"`
+}
+
+// Theme returns the theme of the request by request parameter, zone or the default one
+func (c *ThemeConfig) Theme(request adtype.BidRequester) *Theme {
+	if c == nil {
+		return DefaultTheme.WithDefaults()
+	}
+	if request != nil {
+		if c.AllowRequestTheme && request.HTTPRequest() != nil {
+			if th, ok := c.Themes[string(request.HTTPRequest().QueryArgs().Peek("theme"))]; ok {
+				return th.WithDefaults()
+			}
+		}
+		if th, ok := c.Themes[c.Zones[request.TargetID()]]; ok {
+			return th.WithDefaults()
+		}
+	}
+	return c.Default.WithDefaults()
+}
+
+// cssValue returns the value without characters which can break the CSS rule
+// or the default value if the result is empty
+func cssValue(s, def string) string {
+	s = strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		case strings.ContainsRune(" #%,.()-", r):
+			return r
+		}
+		return -1
+	}, s))
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+func positive(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,12 @@
+package templates
+
+import (
+	"github.com/geniusrabbit/adcorelib/adtype"
+
+	"github.com/geniusrabbit/adstdendpoints/viewability"
+)
+
+// viewRule returns the view rule for the format and the size of the item
+func (r *QTPLRenderer) viewRule(it adtype.ResponseItem) *viewability.Rule {
+	return r.Viewability.ItemRule(it)
+}
@@ -0,0 +1,99 @@
+package templates
+
+import "github.com/geniusrabbit/adcorelib/adtype"
+
+// Widget layouts of the multi-item response
+const (
+	WidgetGrid     = "grid"
+	WidgetList     = "list"
+	WidgetCarousel = "carousel"
+)
+
+// DefaultWidget is used for multi-item responses if the zone has no own widget
+var DefaultWidget = Widget{
+	Layout:  WidgetGrid,
+	Columns: 3,
+	Breakpoints: []WidgetBreakpoint{
+		{MaxWidth: 600, Columns: 2},
+		{MaxWidth: 400, Columns: 1},
+	},
+	Label: "Sponsored",
+}
+
+// WidgetBreakpoint changes the number of columns if the width of the slot is less or equal MaxWidth
+type WidgetBreakpoint struct {
+	MaxWidth int `json:"max_width" yaml:"max_width"`
+	Columns  int `json:"columns" yaml:"columns"`
+}
+
+// Widget describes the rendering of several native items in one document
+type Widget struct {
+	// Layout of the items: `grid`, `list` or `carousel`
+	Layout string `json:"layout" yaml:"layout"`
+
+	// Columns of the grid or visible cards of the carousel, the list always has one column
+	Columns int `json:"columns" yaml:"columns"`
+
+	// Breakpoints of the responsive layout in descending order of MaxWidth,
+	// an empty non-nil list disables the default breakpoints
+	Breakpoints []WidgetBreakpoint `json:"breakpoints" yaml:"breakpoints"`
+
+	// Title of the widget header, no title if empty
+	Title string `json:"title" yaml:"title"`
+
+	// Label of the branding in the header, "Sponsored" if empty
+	Label string `json:"label" yaml:"label"`
+}
+
+// columns returns the positive number of columns of the layout
+func (w *Widget) columns(n int) int {
+	if w.Layout == WidgetList {
+		return 1
+	}
+	return max(n, 1)
+}
+
+// ResponseItems returns all single items of the response
+func ResponseItems(resp adtype.Response) []adtype.ResponseItem {
+	if resp == nil {
+		return nil
+	}
+	var items []adtype.ResponseItem
+	for _, ad := range resp.Ads() {
+		switch it := ad.(type) {
+		case adtype.ResponseItem:
+			items = append(items, it)
+		case adtype.ResponseMultipleItem:
+			items = append(items, it.Ads()...)
+		}
+	}
+	return items
+}
+
+// withDefaults returns the copy of the widget with empty values from DefaultWidget
+func (w Widget) withDefaults() *Widget {
+	if w.Layout == "" {
+		w.Layout = DefaultWidget.Layout
+	}
+	if w.Columns <= 0 {
+		w.Columns = DefaultWidget.Columns
+	}
+	if w.Breakpoints == nil {
+		w.Breakpoints = DefaultWidget.Breakpoints
+	}
+	if w.Label == "" {
+		w.Label = DefaultWidget.Label
+	}
+	return &w
+}
+
+// widget returns the widget of the zone or the default one
+// and true if the response has to be rendered as widget
+func (r *QTPLRenderer) widget(resp adtype.Response, items []adtype.ResponseItem) (*Widget, bool) {
+	if req := resp.Request(); req != nil {
+		if w, ok := r.ZoneWidgets[req.TargetID()]; ok {
+			return w.withDefaults(), true
+		}
+	}
+	return r.Widget.withDefaults(), len(items) > 1
+}
@@ -0,0 +1,62 @@
+package textlink
+
+import "github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+
+// Default names of the content fields of the text ad
+const (
+	DefaultTitleField       = "title"
+	DefaultDescriptionField = "description"
+	DefaultDisplayURLField  = "display_url"
+	DefaultKeywordsField    = "keywords"
+)
+
+// DefaultMaxTextLength of the page text used for the keyword matching.
+// The text is passed in the query, so the request line with the headers
+// must fit the read buffer of the server, 4 KB by default in fasthttp
+const DefaultMaxTextLength = 2 << 10
+
+// Config of the text-link endpoint
+type Config struct {
+	// TitleField of the native content, DefaultTitleField if empty
+	TitleField string `json:"title_field" yaml:"title_field"`
+
+	// DescriptionField of the native content, DefaultDescriptionField if empty
+	DescriptionField string `json:"description_field" yaml:"description_field"`
+
+	// DisplayURLField of the native content, DefaultDisplayURLField if empty.
+	// The host of the action URL is displayed if the field is empty
+	DisplayURLField string `json:"display_url_field" yaml:"display_url_field"`
+
+	// KeywordsField of the native content with comma-separated keywords of the ad,
+	// DefaultKeywordsField if empty. The request keywords are used if the field is empty
+	KeywordsField string `json:"keywords_field" yaml:"keywords_field"`
+
+	// MaxLinks of the in-text placements, unlimited if empty
+	MaxLinks int `json:"max_links" yaml:"max_links"`
+
+	// MaxTextLength of the page text, DefaultMaxTextLength if empty
+	MaxTextLength int `json:"max_text_length" yaml:"max_text_length"`
+}
+
+func (conf *Config) titleField() string {
+	return endpointutil.OrDefault(conf.TitleField, DefaultTitleField)
+}
+
+func (conf *Config) descriptionField() string {
+	return endpointutil.OrDefault(conf.DescriptionField, DefaultDescriptionField)
+}
+
+func (conf *Config) displayURLField() string {
+	return endpointutil.OrDefault(conf.DisplayURLField, DefaultDisplayURLField)
+}
+
+func (conf *Config) keywordsField() string {
+	return endpointutil.OrDefault(conf.KeywordsField, DefaultKeywordsField)
+}
+
+func (conf *Config) maxTextLength() int {
+	if conf.MaxTextLength > 0 {
+		return conf.MaxTextLength
+	}
+	return DefaultMaxTextLength
+}
@@ -0,0 +1,147 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+package textlink
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/context/ctxlogger"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints"
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointutil"
+	"github.com/geniusrabbit/adstdendpoints/templates"
+)
+
+type _endpoint struct {
+	urlGen adtype.URLGenerator
+	conf   Config
+}
+
+// New creates new text-link endpoint
+func New(urlGen adtype.URLGenerator, opts ...Option) *_endpoint {
+	e := &_endpoint{urlGen: urlGen}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Codename of the endpoint
+func (e *_endpoint) Codename() string {
+	return "textlink"
+}
+
+// Handle request of the text ads and return them with the in-text placements
+// matched by the page text and the request keywords
+func (e *_endpoint) Handle(source adstdendpoints.Source, request adtype.BidRequester) adtype.Response {
+	var response adtype.Response
+	if request.IsRobot() {
+		response = bidresponse.NewEmptyResponse(request, nil, nil)
+	} else {
+		response = source.Bid(request)
+	}
+	var (
+		resp       = Response{Items: []*item{}}
+		adKeywords [][]string
+	)
+	if response.Error() == nil {
+		for _, it := range templates.ResponseItems(response) {
+			if res := e.prepareItem(it, response); res != nil {
+				resp.Items = append(resp.Items, res)
+				adKeywords = append(adKeywords, splitKeywords(it.ContentItemString(e.conf.keywordsField())))
+			}
+		}
+	}
+
+	ctx := request.HTTPRequest()
+	text := e.pageText(ctx)
+	if keywords := splitKeywords(request.Tags()...); text != "" || len(keywords) > 0 {
+		resp.Placements = e.placements(resp.Items, adKeywords, keywords, text)
+	}
+
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetContentType("application/json")
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(ctx).Encode(resp); err != nil {
+		ctxlogger.Get(request.Context()).Error("textlink render json", zap.Error(err))
+	}
+	return response
+}
+
+// prepareItem returns the text ad of the item or nil if the item has no title or click URL
+func (e *_endpoint) prepareItem(it adtype.ResponseItem, response adtype.Response) *item {
+	title := it.ContentItemString(e.conf.titleField())
+	link, _ := e.urlGen.ClickURL(it, response)
+	if title == "" || link == "" {
+		return nil
+	}
+	return &item{
+		ID:          it.ID(),
+		Title:       title,
+		Description: it.ContentItemString(e.conf.descriptionField()),
+		DisplayURL:  e.displayURL(it),
+		URL:         link,
+		Impressions: append([]string{endpointutil.PixelURL(e.urlGen, events.Impression, it, response)}, it.ImpressionTrackerLinks()...),
+		Views:       append([]string{endpointutil.PixelURL(e.urlGen, events.View, it, response)}, it.ViewTrackerLinks()...),
+	}
+}
+
+// placements assigns the unique keyword to every item. The keywords of the ad are
+// limited by the request keywords, and must be present in the page text if it's defined
+func (e *_endpoint) placements(items []*item, adKeywords [][]string, keywords []string, text string) []*placement {
+	var (
+		res  []*placement
+		used = map[string]bool{}
+	)
+	for i, it := range items {
+		if e.conf.MaxLinks > 0 && len(res) >= e.conf.MaxLinks {
+			break
+		}
+		candidates := adKeywords[i]
+		if len(candidates) == 0 {
+			candidates = keywords
+		}
+		for _, kw := range candidates {
+			if used[kw] || (len(keywords) > 0 && !slices.Contains(keywords, kw)) ||
+				(text != "" && !containsWord(text, kw)) {
+				continue
+			}
+			used[kw] = true
+			res = append(res, &placement{Keyword: kw, ItemID: it.ID})
+			break
+		}
+	}
+	return res
+}
+
+// pageText returns the lowercase page text from the `text` parameter
+func (e *_endpoint) pageText(ctx *fasthttp.RequestCtx) string {
+	text := ctx.QueryArgs().Peek("text")
+	if maxLen := e.conf.maxTextLength(); len(text) > maxLen {
+		text = text[:maxLen]
+	}
+	return strings.ToLower(strings.ToValidUTF8(string(text), ""))
+}
+
+// displayURL returns the display URL field or the host of the action URL
+func (e *_endpoint) displayURL(it adtype.ResponseItem) string {
+	if val := it.ContentItemString(e.conf.displayURLField()); val != "" {
+		return val
+	}
+	if link, err := url.Parse(it.ActionURL()); err == nil {
+		return strings.TrimPrefix(link.Hostname(), "www.")
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package textlink
+
+import (
+	"encoding/json"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+	"github.com/geniusrabbit/adcorelib/eventtraking/events"
+
+	"github.com/geniusrabbit/adstdendpoints/internal/endpointtest"
+)
+
+func testSource() *endpointtest.Source {
+	return &endpointtest.Source{Items: []adtype.ResponseItemCommon{
+		endpointtest.NewItem("ad1", types.FormatNativeType,
+			map[string]any{"title": "Laptops", "keywords": "laptop, phone"}),
+		&bidresponse.ResponseItemBlock{Items: []adtype.ResponseItem{
+			endpointtest.NewItem("ad2", types.FormatNativeType,
+				map[string]any{"title": "Phones", "keywords": "phone, tablet"}),
+			endpointtest.NewItem("ad3", types.FormatNativeType, map[string]any{"keywords": "laptop"}),
+		}},
+	}}
+}
+
+func handle(t *testing.T, uri string) *Response {
+	t.Helper()
+	request := endpointtest.NewRequest(uri)
+	New(endpointtest.URLGen{}).Handle(testSource(), request)
+
+	ctx := request.HTTPRequest()
+	if code := ctx.Response.StatusCode(); code != 200 {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); ct != "application/json" {
+		t.Errorf("content type = %q, want application/json", ct)
+	}
+	var resp Response
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return &resp
+}
+
+func TestHandle(t *testing.T) {
+	resp := handle(t, "https://ads.example.com/textlink?zone=1&text=Best+LAPTOP+and+phone+deals")
+	if len(resp.Items) != 2 {
+		t.Fatalf("items = %d, want 2 of the single and multiple items", len(resp.Items))
+	}
+	for i, id := range []string{"ad1", "ad2"} {
+		it := resp.Items[i]
+		if it.ID != id || it.URL != endpointtest.ClickURL(id) {
+			t.Errorf("item %d = %q %q, want %s with the click URL", i, it.ID, it.URL, id)
+		}
+		if it.DisplayURL != "advertiser.example.com" {
+			t.Errorf("display URL of %s = %q, want the host of the action URL", id, it.DisplayURL)
+		}
+		imps := []string{endpointtest.PixelURL(events.Impression, id), "https://imp.example.com/" + id}
+		if !slices.Equal(it.Impressions, imps) {
+			t.Errorf("impressions of %s = %v, want %v", id, it.Impressions, imps)
+		}
+		views := []string{endpointtest.PixelURL(events.View, id), "https://view.example.com/" + id}
+		if !slices.Equal(it.Views, views) {
+			t.Errorf("views of %s = %v, want %v", id, it.Views, views)
+		}
+	}
+	want := []placement{{Keyword: "laptop", ItemID: "ad1"}, {Keyword: "phone", ItemID: "ad2"}}
+	if len(resp.Placements) != len(want) {
+		t.Fatalf("placements = %d, want %d", len(resp.Placements), len(want))
+	}
+	for i, pl := range resp.Placements {
+		if *pl != want[i] {
+			t.Errorf("placement %d = %+v, want %+v", i, *pl, want[i])
+		}
+	}
+}
+
+func TestHandleWithoutText(t *testing.T) {
+	resp := handle(t, "https://ads.example.com/textlink?zone=1")
+	if len(resp.Items) != 2 {
+		t.Errorf("items = %d, want 2", len(resp.Items))
+	}
+	if len(resp.Placements) != 0 {
+		t.Errorf("placements = %+v, want none without the page text and keywords", resp.Placements)
+	}
+}
+
+func TestHandleTextLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []placement
+	}{
+		{
+			name: "at_limit",
+			text: strings.Repeat("x", DefaultMaxTextLength-len("+laptop")) + "+laptop",
+			want: []placement{{Keyword: "laptop", ItemID: "ad1"}},
+		},
+		{
+			name: "over_limit",
+			text: strings.Repeat("x", DefaultMaxTextLength-len("+laptop")+1) + "+laptop",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handle(t, "https://ads.example.com/textlink?zone=1&text="+tt.text)
+			if len(resp.Placements) != len(tt.want) {
+				t.Fatalf("placements = %+v, want %+v", resp.Placements, tt.want)
+			}
+			for i, pl := range resp.Placements {
+				if *pl != tt.want[i] {
+					t.Errorf("placement %d = %+v, want %+v", i, *pl, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultMaxTextLengthFitsReadBuffer(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+	var received int
+	server := &fasthttp.Server{Handler: func(ctx *fasthttp.RequestCtx) {
+		received = len(ctx.QueryArgs().Peek("text"))
+	}}
+	go func() { _ = server.Serve(ln) }()
+	defer func() { _ = server.Shutdown() }()
+
+	client := &fasthttp.Client{Dial: func(string) (net.Conn, error) { return ln.Dial() }}
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI("http://ads.example.com/textlink?zone=1&count=5&keywords=laptop,phone&text=" +
+		strings.Repeat("x", DefaultMaxTextLength))
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Referer", "https://publisher.example.com/articles/2025/best-laptops-and-phones-of-the-year")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Cookie", "uid="+strings.Repeat("u", 256))
+	if err := client.Do(req, resp); err != nil {
+		t.Fatalf("request at the limit error = %v", err)
+	}
+	if resp.StatusCode() != fasthttp.StatusOK || received != DefaultMaxTextLength {
+		t.Errorf("request at the limit: status = %d, text = %d bytes, want %d", resp.StatusCode(), received, DefaultMaxTextLength)
+	}
+}
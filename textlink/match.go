@@ -0,0 +1,60 @@
+package textlink
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// splitKeywords returns the trimmed lowercase keywords without duplicates
+func splitKeywords(list ...string) []string {
+	var (
+		res  []string
+		seen = map[string]bool{}
+	)
+	for _, val := range list {
+		for _, kw := range strings.Split(val, ",") {
+			kw = strings.ToLower(strings.TrimSpace(kw))
+			if kw != "" && !seen[kw] {
+				seen[kw] = true
+				res = append(res, kw)
+			}
+		}
+	}
+	return res
+}
+
+// containsWord returns true if the lowercase text contains the keyword as the whole word or phrase
+func containsWord(text, keyword string) bool {
+	for offset := 0; offset < len(text); {
+		idx := strings.Index(text[offset:], keyword)
+		if idx < 0 {
+			return false
+		}
+		start, end := offset+idx, offset+idx+len(keyword)
+		if isBoundary(text, start, end) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+func isBoundary(text string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
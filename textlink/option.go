@@ -0,0 +1,11 @@
+package textlink
+
+// Option of the text-link endpoint
+type Option func(e *_endpoint)
+
+// WithConfig sets the endpoint config
+func WithConfig(conf Config) Option {
+	return func(e *_endpoint) {
+		e.conf = conf
+	}
+}
@@ -0,0 +1,26 @@
+package textlink
+
+//easyjson:json
+type item struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	DisplayURL  string   `json:"display_url,omitempty"`
+	URL         string   `json:"url"`
+	Impressions []string `json:"impressions,omitempty"`
+	Views       []string `json:"views,omitempty"`
+}
+
+//easyjson:json
+type placement struct {
+	Keyword string `json:"keyword"`
+	ItemID  string `json:"item_id"`
+}
+
+// Response with the text ads and the in-text placements
+//
+//easyjson:json
+type Response struct {
+	Items      []*item      `json:"items"`
+	Placements []*placement `json:"placements,omitempty"`
+}
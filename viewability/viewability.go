@@ -0,0 +1,157 @@
+//
+// @project GeniusRabbit adstdendpoints 2025
+// @author Dmitry Ponomarev <demdxx@gmail.com> 2025
+//
+
+// Package viewability describes when the view of the ad is counted.
+// The same rules are used by the rendered documents and returned
+// to the client-side renderers, so the view is measured equally everywhere.
+package viewability
+
+import (
+	"slices"
+
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+// DefaultLargeFormatArea in pixels from which the format is considered as large, 970x250 and bigger
+const DefaultLargeFormatArea = 242500
+
+// Default rules
+var (
+	// DefaultRule is the MRC standard: 50% of pixels visible during 1 second
+	DefaultRule = Rule{MinVisibleRatio: 0.5, MinDurationMs: 1000}
+
+	// DefaultLargeRule for large formats: 100% of pixels visible during 2 seconds
+	DefaultLargeRule = Rule{MinVisibleRatio: 1, MinDurationMs: 2000}
+)
+
+// Rule describes when the view is counted
+type Rule struct {
+	// MinVisibleRatio of the ad area from 0 to 1
+	MinVisibleRatio float64 `json:"min_visible_ratio" yaml:"min_visible_ratio"`
+
+	// MinDurationMs of the continuous visibility in milliseconds
+	MinDurationMs int `json:"min_duration_ms" yaml:"min_duration_ms"`
+}
+
+// IsEmpty rule without thresholds
+func (r *Rule) IsEmpty() bool {
+	return r == nil || (r.MinVisibleRatio <= 0 && r.MinDurationMs <= 0)
+}
+
+// VisibleRatio returns the visible ratio in range (0, 1]
+func (r *Rule) VisibleRatio() float64 {
+	switch {
+	case r == nil || r.MinVisibleRatio <= 0:
+		return DefaultRule.MinVisibleRatio
+	case r.MinVisibleRatio > 1:
+		return 1
+	}
+	return r.MinVisibleRatio
+}
+
+// Duration returns the non-negative dwell time in milliseconds
+func (r *Rule) Duration() int {
+	if r == nil {
+		return 0
+	}
+	return max(r.MinDurationMs, 0)
+}
+
+// VerificationResource describes the measurement vendor script (OMID-style verification)
+type VerificationResource struct {
+	// Vendor key of the measurement provider
+	Vendor string `json:"vendor" yaml:"vendor"`
+
+	// ScriptURL of the verification script
+	ScriptURL string `json:"script_url" yaml:"script_url"`
+
+	// APIFramework of the script (default: `omid`)
+	APIFramework string `json:"api_framework,omitempty" yaml:"api_framework"`
+
+	// Params passed to the verification script
+	Params string `json:"params,omitempty" yaml:"params"`
+
+	// Formats codenames where the script is used, all formats if empty
+	Formats []string `json:"-" yaml:"formats"`
+}
+
+// Config of the view measurement rules
+type Config struct {
+	// Default rule for all formats (MRC 50%/1s if empty)
+	Default Rule `json:"default" yaml:"default"`
+
+	// Large rule for formats with area bigger than LargeArea (100%/2s if empty)
+	Large Rule `json:"large" yaml:"large"`
+
+	// LargeArea in pixels from which the format is considered as large
+	LargeArea int `json:"large_area" yaml:"large_area"`
+
+	// Formats custom rules by format codename
+	Formats map[string]Rule `json:"formats" yaml:"formats"`
+
+	// Verifications list of the measurement vendor scripts
+	Verifications []VerificationResource `json:"verifications" yaml:"verifications"`
+}
+
+// Rule returns the viewability rule for the format, the default rules are used for the nil config
+func (c *Config) Rule(codename string, width, height int) *Rule {
+	if c == nil {
+		c = &Config{}
+	}
+	if rule, ok := c.Formats[codename]; ok && !rule.IsEmpty() {
+		return &rule
+	}
+	largeArea := c.LargeArea
+	if largeArea <= 0 {
+		largeArea = DefaultLargeFormatArea
+	}
+	if width*height >= largeArea {
+		if !c.Large.IsEmpty() {
+			return &c.Large
+		}
+		return &DefaultLargeRule
+	}
+	if !c.Default.IsEmpty() {
+		return &c.Default
+	}
+	return &DefaultRule
+}
+
+// ItemRule returns the viewability rule by the format and the size of the item,
+// the size of the format is used if the item has no own size
+func (c *Config) ItemRule(item adtype.ResponseItem) *Rule {
+	if item == nil {
+		return c.Rule("", 0, 0)
+	}
+	var (
+		codename      string
+		width, height = item.Width(), item.Height()
+	)
+	if format := item.Format(); format != nil {
+		codename = format.Codename
+		if width <= 0 || height <= 0 {
+			width, height = format.Width, format.Height
+		}
+	}
+	return c.Rule(codename, width, height)
+}
+
+// VerificationsFor returns the verification scripts for the format
+func (c *Config) VerificationsFor(codename string) []VerificationResource {
+	if c == nil {
+		return nil
+	}
+	var list []VerificationResource
+	for _, res := range c.Verifications {
+		if len(res.Formats) > 0 && !slices.Contains(res.Formats, codename) {
+			continue
+		}
+		if res.APIFramework == "" {
+			res.APIFramework = "omid"
+		}
+		list = append(list, res)
+	}
+	return list
+}
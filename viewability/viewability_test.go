@@ -0,0 +1,93 @@
+package viewability
+
+import (
+	"testing"
+
+	"github.com/geniusrabbit/adcorelib/admodels/types"
+	"github.com/geniusrabbit/adcorelib/adquery/bidresponse"
+	"github.com/geniusrabbit/adcorelib/adtype"
+)
+
+func TestConfigRule(t *testing.T) {
+	custom := Rule{MinVisibleRatio: 0.3, MinDurationMs: 500}
+	conf := &Config{
+		Formats: map[string]Rule{"video": {MinVisibleRatio: 0.5, MinDurationMs: 2000}, "empty": {}},
+	}
+	tests := []struct {
+		name     string
+		conf     *Config
+		codename string
+		w, h     int
+		want     Rule
+	}{
+		{name: "nil_default", conf: nil, w: 300, h: 250, want: DefaultRule},
+		{name: "nil_large", conf: nil, w: 970, h: 250, want: DefaultLargeRule},
+		{name: "format", conf: conf, codename: "video", w: 970, h: 250, want: Rule{MinVisibleRatio: 0.5, MinDurationMs: 2000}},
+		{name: "empty_format", conf: conf, codename: "empty", w: 300, h: 250, want: DefaultRule},
+		{name: "custom_default", conf: &Config{Default: custom}, w: 300, h: 250, want: custom},
+		{name: "custom_large", conf: &Config{Large: custom}, w: 1000, h: 1000, want: custom},
+		{name: "custom_area", conf: &Config{LargeArea: 300 * 250}, w: 300, h: 250, want: DefaultLargeRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conf.Rule(tt.codename, tt.w, tt.h); *got != tt.want {
+				t.Errorf("Rule() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigItemRule(t *testing.T) {
+	item := &bidresponse.ResponseItemBlank{
+		Imp:       &adtype.Impression{ID: "imp1"},
+		FormatVal: &types.Format{Codename: "billboard", Width: 970, Height: 250},
+	}
+	var conf *Config
+	if got := conf.ItemRule(item); *got != DefaultLargeRule {
+		t.Errorf("ItemRule() by the format size = %+v, want %+v", *got, DefaultLargeRule)
+	}
+	conf = &Config{Formats: map[string]Rule{"billboard": {MinVisibleRatio: 0.8}}}
+	if got := conf.ItemRule(item); got.MinVisibleRatio != 0.8 {
+		t.Errorf("ItemRule() by the format codename = %+v", *got)
+	}
+	if got := conf.ItemRule(nil); *got != DefaultRule {
+		t.Errorf("ItemRule(nil) = %+v, want %+v", *got, DefaultRule)
+	}
+}
+
+func TestRuleValues(t *testing.T) {
+	tests := []struct {
+		rule     *Rule
+		ratio    float64
+		duration int
+	}{
+		{rule: nil, ratio: 0.5, duration: 0},
+		{rule: &Rule{}, ratio: 0.5, duration: 0},
+		{rule: &Rule{MinVisibleRatio: 2, MinDurationMs: -1}, ratio: 1, duration: 0},
+		{rule: &Rule{MinVisibleRatio: 0.3, MinDurationMs: 1500}, ratio: 0.3, duration: 1500},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.VisibleRatio(); got != tt.ratio {
+			t.Errorf("%+v.VisibleRatio() = %v, want %v", tt.rule, got, tt.ratio)
+		}
+		if got := tt.rule.Duration(); got != tt.duration {
+			t.Errorf("%+v.Duration() = %v, want %v", tt.rule, got, tt.duration)
+		}
+	}
+}
+
+func TestVerificationsFor(t *testing.T) {
+	conf := &Config{Verifications: []VerificationResource{
+		{Vendor: "all", ScriptURL: "https://a.example.com/v.js"},
+		{Vendor: "video", ScriptURL: "https://b.example.com/v.js", APIFramework: "custom", Formats: []string{"video"}},
+	}}
+	if list := conf.VerificationsFor("banner"); len(list) != 1 || list[0].APIFramework != "omid" {
+		t.Errorf("VerificationsFor(banner) = %+v", list)
+	}
+	if list := conf.VerificationsFor("video"); len(list) != 2 || list[1].APIFramework != "custom" {
+		t.Errorf("VerificationsFor(video) = %+v", list)
+	}
+	if list := (*Config)(nil).VerificationsFor("video"); list != nil {
+		t.Errorf("VerificationsFor() of nil config = %+v", list)
+	}
+}